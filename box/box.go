@@ -1,5 +1,13 @@
 package box
 
+import (
+	"strings"
+
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+)
+
+// The characters of the Heavy box style, kept for code that draws boxes directly.
 const (
 	Upright      string = "┃"
 	DividerLeft  string = "┣"
@@ -10,3 +18,77 @@ const (
 	EndLeft      string = "┗"
 	EndRight     string = "┛"
 )
+
+// BoxStyle is a set of characters used to draw the frame around a page.
+type BoxStyle struct {
+	Name         string
+	Upright      string
+	DividerLeft  string
+	DividerRight string
+	StartLeft    string
+	StartRight   string
+	Horizontal   string
+	EndLeft      string
+	EndRight     string
+}
+
+var (
+	// Heavy is drawn with thick lines, and is the default.
+	Heavy = BoxStyle{"Heavy", Upright, DividerLeft, DividerRight, StartLeft, StartRight, Horizontal, EndLeft, EndRight}
+	// Light is drawn with thin lines.
+	Light = BoxStyle{"Light", "│", "├", "┤", "┌", "┐", "─", "└", "┘"}
+	// Double is drawn with double lines.
+	Double = BoxStyle{"Double", "║", "╠", "╣", "╔", "╗", "═", "╚", "╝"}
+	// Rounded is drawn with thin lines and rounded corners.
+	Rounded = BoxStyle{"Rounded", "│", "├", "┤", "╭", "╮", "─", "╰", "╯"}
+	// ASCII is drawn with plain ASCII characters, for serial consoles and terminals without box drawing glyphs.
+	ASCII = BoxStyle{"ASCII", "|", "+", "+", "+", "+", "-", "+", "+"}
+)
+
+// styles are the box styles that can be chosen by name.
+var styles = []BoxStyle{Heavy, Light, Double, Rounded, ASCII}
+
+func init() {
+	conf.AddCheck("BoxStyle", func(c conf.Config) error {
+		if c.BoxStyle == "" {
+			return nil
+		}
+		_, err := Lookup(c.BoxStyle)
+		return err
+	})
+}
+
+// Styles returns the names of the box styles that can be chosen.
+func Styles() []string {
+	var names []string
+	for _, s := range styles {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+// Lookup returns the box style with the given name, ignoring case.
+func Lookup(name string) (BoxStyle, error) {
+	for _, s := range styles {
+		if strings.EqualFold(s.Name, name) {
+			return s, nil
+		}
+	}
+	return Heavy, errs.ErrUnknownBoxStyle.With(name)
+}
+
+// Default returns the box style named by BoxStyle in the configuration in use, see Configured.
+func Default() BoxStyle {
+	return Configured(*conf.Current())
+}
+
+// Configured returns the box style named by BoxStyle in c, or Heavy if none is set. The name is
+// checked when the configuration is validated, so an unknown name is only drawn as Heavy if the
+// configuration was set without being loaded.
+func Configured(c conf.Config) BoxStyle {
+	if c.BoxStyle == "" {
+		return Heavy
+	}
+	s, _ := Lookup(c.BoxStyle)
+	return s
+}
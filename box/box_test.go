@@ -0,0 +1,41 @@
+package box
+
+import (
+	"errors"
+	"testing"
+
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+)
+
+func Test_Lookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    BoxStyle
+		wantErr error
+	}{
+		{"heavy", Heavy, nil},
+		{"ASCII", ASCII, nil},
+		{"Rounded", Rounded, nil},
+		{"dotted", Heavy, errs.ErrUnknownBoxStyle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Lookup(tt.name)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Lookup() error = %v, want %v", err, tt.wantErr)
+			}
+			c := conf.Defaults()
+			c.BoxStyle = tt.name
+			if err := c.Validate(); !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("Validate() with BoxStyle %v = %v, want %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Lookup() = %v, want %v", got.Name, tt.want.Name)
+			}
+			if got := Configured(c); got != tt.want {
+				t.Errorf("Configured() with BoxStyle %v = %v, want %v", tt.name, got.Name, tt.want.Name)
+			}
+		})
+	}
+}
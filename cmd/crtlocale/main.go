@@ -0,0 +1,180 @@
+// Command crtlocale helps translate the messages built into crt.
+//
+//	crtlocale template [-format json|po] [-o dir]   write a catalog of every message for translators
+//	crtlocale pseudo [-o dir]                       write a pseudo-localised catalog, en-xa.json
+//	crtlocale missing dir                           report the messages missing from each locale in dir
+//
+// The pseudo-localised catalog accents every message and makes it 30% longer. Setting Locale=en-xa,
+// with LocaleDir set to the directory holding the catalog, shows where layouts truncate or overflow
+// text that is longer than the English. The missing report exits with status 1 if any message is
+// missing, so it can be used as a check.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	lang "github.com/mt1976/crt/language"
+)
+
+// pseudoLocale is the locale of the pseudo-localised catalog, the tag reserved for pseudo-locales.
+const pseudoLocale = "en-xa"
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	var err error
+	switch os.Args[1] {
+	case "template":
+		err = runTemplate(os.Args[2:])
+	case "pseudo":
+		err = runPseudo(os.Args[2:])
+	case "missing":
+		var missing bool
+		missing, err = runMissing(os.Args[2:], os.Stdout)
+		if err == nil && missing {
+			os.Exit(1)
+		}
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "crtlocale:", err)
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: crtlocale template [-format json|po] [-o dir]")
+	fmt.Fprintln(os.Stderr, "       crtlocale pseudo [-o dir]")
+	fmt.Fprintln(os.Stderr, "       crtlocale missing dir")
+	os.Exit(2)
+}
+
+// messages returns every built in message, by ID.
+func messages() map[string]string {
+	out := map[string]string{}
+	for _, id := range lang.IDs() {
+		out[id], _ = lang.Message(id)
+	}
+	return out
+}
+
+func runTemplate(args []string) error {
+	flags := flag.NewFlagSet("template", flag.ExitOnError)
+	format := flags.String("format", "json", "the catalog format, json or po")
+	dir := flags.String("o", ".", "the directory to write the catalog to")
+	flags.Parse(args)
+
+	switch *format {
+	case "json":
+		return writeJSON(filepath.Join(*dir, "messages.json"), messages())
+	case "po":
+		f, err := os.Create(filepath.Join(*dir, "messages.pot"))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return writePO(f, lang.IDs())
+	}
+	return fmt.Errorf("unknown format %q", *format)
+}
+
+func runPseudo(args []string) error {
+	flags := flag.NewFlagSet("pseudo", flag.ExitOnError)
+	dir := flags.String("o", ".", "the directory to write the catalog to")
+	flags.Parse(args)
+
+	catalog := messages()
+	for id, msg := range catalog {
+		catalog[id] = pseudo(msg)
+	}
+	return writeJSON(filepath.Join(*dir, pseudoLocale+".json"), catalog)
+}
+
+// runMissing loads the catalogs in a directory and reports, for each locale, the messages with no
+// translation and any translations for messages that no longer exist. It returns true if any
+// message is missing.
+func runMissing(args []string, out io.Writer) (bool, error) {
+	if len(args) != 1 {
+		return false, fmt.Errorf("missing needs the directory holding the catalogs")
+	}
+	if err := lang.LoadCatalogs(args[0]); err != nil {
+		return false, err
+	}
+	ids := lang.IDs()
+	anyMissing := false
+	for _, tag := range lang.Locales() {
+		translations := lang.Translations(tag)
+		var missing, unknown []string
+		for _, id := range ids {
+			if strings.TrimSpace(translations[id]) == "" {
+				missing = append(missing, id)
+			}
+		}
+		for _, id := range sortedKeys(translations) {
+			if _, ok := lang.Message(id); !ok {
+				unknown = append(unknown, id)
+			}
+		}
+		fmt.Fprintf(out, "%v: %v of %v messages translated\n", tag, len(ids)-len(missing), len(ids))
+		for _, id := range missing {
+			fmt.Fprintf(out, "  missing %v\n", id)
+		}
+		for _, id := range unknown {
+			fmt.Fprintf(out, "  unknown %v\n", id)
+		}
+		anyMissing = anyMissing || len(missing) > 0
+	}
+	return anyMissing, nil
+}
+
+func writeJSON(path string, catalog map[string]string) error {
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// writePO writes a gettext template, with the message ID as the msgid and the English as a comment
+// for the translator.
+func writePO(w io.Writer, ids []string) error {
+	fmt.Fprintln(w, `msgid ""`)
+	fmt.Fprintln(w, `msgstr ""`)
+	fmt.Fprintln(w, `"Content-Type: text/plain; charset=UTF-8\n"`)
+	for _, id := range ids {
+		msg, _ := lang.Message(id)
+		fmt.Fprintln(w)
+		for _, line := range strings.Split(msg, "\n") {
+			fmt.Fprintf(w, "#. %v\n", line)
+		}
+		fmt.Fprintf(w, "msgid %v\n", poQuote(id))
+		if _, err := fmt.Fprintln(w, `msgstr ""`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// poQuote quotes a string for a .po file.
+func poQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// accents maps letters to an accented form of the same width, so pseudo-localised text is still
+// readable but obviously not English.
+var accents = map[rune]rune{
+	'a': 'á', 'c': 'ç', 'd': 'ð', 'e': 'é', 'g': 'ĝ', 'h': 'ĥ', 'i': 'í', 'k': 'ķ', 'l': 'ļ', 'n': 'ñ',
+	'o': 'ö', 'r': 'ŕ', 's': 'š', 't': 'ţ', 'u': 'ü', 'w': 'ŵ', 'y': 'ý', 'z': 'ž',
+	'A': 'Å', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î', 'K': 'Ķ', 'L': 'Ļ', 'N': 'Ñ',
+	'O': 'Ø', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ', 'U': 'Û', 'W': 'Ŵ', 'Y': 'Ý', 'Z': 'Ž',
+}
+
+// expansion is how much longer pseudo-localised text is than the English, as translations often are.
+const expansion = 0.3
+
+// pseudo returns a pseudo-localised message: the text is accented, padded by 30% and wrapped in
+// brackets, so truncation shows as a missing bracket. Placeholders, their formats and the keys of
+// plural and select branches are left as they are, so the message still fills in.
+func pseudo(message string) string {
+	if strings.TrimSpace(message) == "" {
+		return message
+	}
+	s := &scanner{source: message}
+	body := s.text(false)
+	return "[" + body + strings.Repeat("~", int(math.Ceil(float64(s.letters)*expansion))) + "]"
+}
+
+// scanner copies a message, accenting its text, in the syntax of the language/template package.
+type scanner struct {
+	source  string
+	pos     int
+	letters int // The number of letters accented, used to size the padding
+}
+
+// text copies text up to the end of the message or, if nested in a branch, its closing brace.
+func (s *scanner) text(nested bool) string {
+	var out strings.Builder
+	for s.pos < len(s.source) {
+		rest := s.source[s.pos:]
+		switch {
+		case strings.HasPrefix(rest, "{{") || (!nested && strings.HasPrefix(rest, "}}")):
+			out.WriteString(rest[:2])
+			s.pos += 2
+		case rest[0] == '{':
+			out.WriteString(s.placeholder())
+		case rest[0] == '}' && nested:
+			return out.String()
+		case rest[0] == '%' && len(rest) > 1:
+			// A printf verb, such as %v, copied whole even if the character after the % is multibyte
+			_, size := utf8.DecodeRuneInString(rest[1:])
+			out.WriteString(rest[:1+size])
+			s.pos += 1 + size
+		default:
+			r, size := utf8.DecodeRuneInString(rest)
+			if unicode.IsLetter(r) {
+				s.letters++
+			}
+			if a, ok := accents[r]; ok {
+				r = a
+			}
+			out.WriteRune(r)
+			s.pos += size
+		}
+	}
+	return out.String()
+}
+
+// placeholder copies a placeholder, such as {name} or {n, plural, one {...} other {...}}, accenting
+// only the text of its branches.
+func (s *scanner) placeholder() string {
+	start := s.pos
+	end := strings.IndexAny(s.source[s.pos:], ",}")
+	if end < 0 {
+		s.pos = len(s.source)
+		return s.source[start:]
+	}
+	s.pos += end + 1
+	if s.source[s.pos-1] == '}' {
+		return s.source[start:s.pos]
+	}
+	format := s.source[s.pos:]
+	if i := strings.IndexAny(format, ",}"); i >= 0 {
+		format = format[:i]
+	}
+	switch strings.TrimSpace(format) {
+	case "plural", "select", "selectordinal":
+	default:
+		end := strings.IndexByte(s.source[s.pos:], '}')
+		if end < 0 {
+			s.pos = len(s.source)
+			return s.source[start:]
+		}
+		s.pos += end + 1
+		return s.source[start:s.pos]
+	}
+
+	var out strings.Builder
+	out.WriteString(s.source[start:s.pos])
+	for s.pos < len(s.source) {
+		c := s.source[s.pos]
+		s.pos++
+		out.WriteByte(c)
+		switch c {
+		case '{':
+			out.WriteString(s.text(true))
+			if s.pos < len(s.source) {
+				out.WriteByte('}')
+				s.pos++
+			}
+		case '}':
+			return out.String()
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func Test_pseudo(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{"Text", "Quit", "[Qüíţ~~]"},
+		{"Blank", " ", " "},
+		{"Placeholder", "Page {page} of {pages}", "[Páĝé {page} öf {pages}~~]"},
+		{"Formatted placeholder", "Due {when, date, 02 Jan}", "[Ðüé {when, date, 02 Jan}~]"},
+		{"Plural", "{n, plural, one {# item} other {# items}}", "[{n, plural, one {# íţém} other {# íţémš}}~~~]"},
+		{"Escaped braces", "{{x}}", "[{{x}}~]"},
+		{"Printf verb", "%v rows", "[%v ŕöŵš~~]"},
+		{"Percent before a multibyte letter", "100%é", "[100%é]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pseudo(tt.message); got != tt.want {
+				t.Errorf("pseudo() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,20 @@
 package config
 
 import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	beep "github.com/gen2brain/beeep"
+	errs "github.com/mt1976/crt/errors"
 	symb "github.com/mt1976/crt/strings/symbols"
+	pflag "github.com/spf13/pflag"
 	viper "github.com/spf13/viper"
 )
 
@@ -20,12 +29,6 @@ type Config struct {
 	TitleLength                int     `mapstructure:"TitleLength"`
 	Debug                      bool    `mapstructure:"Debug"`
 	DefaultErrorDelay          float64 `mapstructure:"DefaultErrorDelay"`
-	DefaultRandomPortMin       int     `mapstructure:"DefaultRandomPortMin"`
-	DefaultRandomPortMax       int     `mapstructure:"DefaultRandomPortMax"`
-	DefaultRandomMACMin        int     `mapstructure:"DefaultRandomMACMin"`
-	DefaultRandomMACMax        int     `mapstructure:"DefaultRandomMACMax"`
-	DefaultRandomIPMin         int     `mapstructure:"DefaultRandomIPMin"`
-	DefaultRandomIPMax         int     `mapstructure:"DefaultRandomIPMax"`
 	DefaultBaud                int     `mapstructure:"DefaultBaud"`
 	DefaultBeepDuration        int
 	DefaultBeepFrequency       float64
@@ -33,55 +36,350 @@ type Config struct {
 	ValidFileNameCharacters    []string
 	PageDumpActive             bool   `mapstructure:"PageDumpActive"`
 	PageDumpPath               string `mapstructure:"PageDumpPath"`
+	Theme                      string `mapstructure:"Theme"`
+	ThemeFile                  string `mapstructure:"ThemeFile"`
+	BoxStyle                   string `mapstructure:"BoxStyle"`
+	SpinnerFile                string `mapstructure:"SpinnerFile"`
+	Locale                     string `mapstructure:"Locale"`
+	LocaleDir                  string `mapstructure:"LocaleDir"`
+	Currency                   string `mapstructure:"Currency"`
+	KeyBindings                string `mapstructure:"KeyBindings"`
+
+	// Deprecated: the DefaultRandom settings belong to the mock package, read them with mock.Config.
+	// They are copies of its section's values, kept for a release, and are not settings of their own.
+	DefaultRandomPortMin int `mapstructure:"-" alias:"DefaultRandom.PortMin"`
+	DefaultRandomPortMax int `mapstructure:"-" alias:"DefaultRandom.PortMax"`
+	DefaultRandomMACMin  int `mapstructure:"-" alias:"DefaultRandom.MACMin"`
+	DefaultRandomMACMax  int `mapstructure:"-" alias:"DefaultRandom.MACMax"`
+	DefaultRandomIPMin   int `mapstructure:"-" alias:"DefaultRandom.IPMin"`
+	DefaultRandomIPMax   int `mapstructure:"-" alias:"DefaultRandom.IPMax"`
+}
+
+// Configuration holds the built in defaults. It is not updated when the configuration is loaded, as
+// it could not be replaced safely while other goroutines read it.
+//
+// Deprecated: Current is the only source of the configuration in use.
+var Configuration = Defaults()
+
+// active is the configuration in use, replaced as a whole each time the configuration is loaded so
+// it can be read from any goroutine.
+var active atomic.Pointer[loaded]
+
+// Current returns the configuration in use. It holds the built in defaults until Load is called. The
+// configuration returned must not be modified; call Current again to see a later reload.
+func Current() *Config {
+	return &state().config
+}
+
+// state returns the loaded configuration in use, starting with the defaults.
+func state() *loaded {
+	if l := active.Load(); l != nil {
+		return l
+	}
+	active.CompareAndSwap(nil, &loaded{config: Defaults(), origins: map[string]Origin{}})
+	return active.Load()
+}
+
+// Options controls where Load looks for configuration. Settings are taken from, in increasing order
+// of precedence:
+//
+//  1. the built in defaults
+//  2. the system configuration file, such as /etc/crt/terminal.yaml
+//  3. the user configuration file, such as ~/.config/crt/terminal.yaml
+//  4. the application configuration file, File or the first found in Paths, such as ./terminal.env
+//  5. environment variables, named by the prefix and the setting, such as CRT_TITLELENGTH
+//  6. command line flags that were given, such as --title-length, see BindFlags
+//
+// Configuration files can be env, yaml, toml or json files, named Name with the extension of their
+// format. A nil list of paths uses the default locations; an empty list searches none.
+type Options struct {
+	Name        string         // The name of the files, without their extension
+	Type        string         // Only read files of this format, such as yaml, rather than any
+	File        string         // The application configuration file, used instead of searching Paths
+	Paths       []string       // The directories searched for the application configuration file
+	UserPaths   []string       // The directories searched for the user configuration file
+	SystemPaths []string       // The directories searched for the system configuration file
+	EnvPrefix   string         // The prefix of environment variables, CRT by default
+	Flags       *pflag.FlagSet // Command line flags to overlay, if any
+	Required    bool           // Fail if there is no configuration file, rather than using the defaults
 }
 
-var Configuration = Config{}
+// DefaultOptions returns the options for terminal files in the standard locations: /etc/crt, the
+// user's configuration directory, the working directory and its config directory.
+func DefaultOptions() Options {
+	return Options{
+		Name:        "terminal",
+		Paths:       []string{".", "config"},
+		UserPaths:   userPaths(),
+		SystemPaths: systemPaths(),
+		EnvPrefix:   "CRT",
+	}
+}
 
-// init reads the configuration file and sets up the configuration object
-func init() {
-	// add the path to the configuration file to the list of paths to search
-	viper.AddConfigPath(".")
-	viper.AddConfigPath("config")
-	// set the name of the configuration file to be loaded
-	viper.SetConfigName("terminal")
-	// set the type of the configuration file (in this case, it is an environment file)
-	viper.SetConfigType("env")
-	// enable automatic environment variable loading
-	viper.AutomaticEnv()
+// withDefaults returns the options with any unset fields taken from DefaultOptions.
+func (o Options) withDefaults() Options {
+	def := DefaultOptions()
+	if o.Name == "" {
+		o.Name = def.Name
+	}
+	if o.Paths == nil {
+		o.Paths = def.Paths
+	}
+	if o.UserPaths == nil {
+		o.UserPaths = def.UserPaths
+	}
+	if o.SystemPaths == nil {
+		o.SystemPaths = def.SystemPaths
+	}
+	if o.EnvPrefix == "" {
+		o.EnvPrefix = def.EnvPrefix
+	}
+	return o
+}
 
-	// read in the configuration file
-	err := viper.ReadInConfig()
+func userPaths() []string {
+	dir, err := os.UserConfigDir()
 	if err != nil {
-		// handle the error
-		return
+		return []string{}
+	}
+	return []string{filepath.Join(dir, "crt")}
+}
+
+func systemPaths() []string {
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("ProgramData"); dir != "" {
+			return []string{filepath.Join(dir, "crt")}
+		}
+		return []string{}
+	}
+	return []string{"/etc/crt"}
+}
+
+// Defaults returns the built in configuration, used for any setting the configuration file does
+// not give.
+func Defaults() Config {
+	return Config{
+		ApplicationDateFormat:      "02 Jan 2006",
+		ApplicationDateFormatShort: "02/01/06",
+		ApplicationTimeFormat:      "15:04",
+		Delay:                      0,
+		Baud:                       0,
+		MaxContentRows:             18,
+		MaxNoItems:                 15,
+		TitleLength:                40,
+		DefaultErrorDelay:          3.0,
+		DefaultRandomPortMin:       1,
+		DefaultRandomPortMax:       65535,
+		DefaultRandomMACMin:        0,
+		DefaultRandomMACMax:        255,
+		DefaultRandomIPMin:         1,
+		DefaultRandomIPMax:         255,
+		DefaultBaud:                0,
+		DefaultBeepDuration:        beep.DefaultDuration,
+		DefaultBeepFrequency:       beep.DefaultFreq,
+		ValidBaudRates:             []int{0, 300, 1200, 2400, 4800, 9600, 19200, 38400, 57600, 115200},
+		ValidFileNameCharacters:    validFileNameCharacters(),
+		PageDumpPath:               "dumps",
+	}
+}
+
+// validFileNameCharacters returns the characters allowed in file names: letters, digits, spaces and
+// some punctuation.
+func validFileNameCharacters() []string {
+	chars := []string{" ", "-", "_", ".", "(", ")", "[", "]", "!"}
+	for _, set := range []string{"1234567890", "abcdefghijklmnopqrstuvwxyz", "ABCDEFGHIJKLMNOPQRSTUVWXYZ"} {
+		for _, c := range set {
+			chars = append(chars, string(c))
+		}
+	}
+	return chars
+}
+
+// Load reads the configuration from the sources described by opts over the defaults, validates the
+// result and, if it is valid, makes it the configuration returned by Current, along with the
+// sections added with Register, and tells the OnChange subscribers. A missing file is only an error,
+// wrapping ErrConfigNotFound, if opts.Required is set or opts.File names one that does not exist.
+func Load(opts Options) error {
+	l, err := read(opts)
+	if err != nil {
+		return err
+	}
+	l.apply()
+	return nil
+}
+
+// loaded is a configuration that has been read and validated, but is not yet active.
+type loaded struct {
+	config   Config
+	sections []sectionValue
+	origins  map[string]Origin
+}
+
+// read returns the configuration from the sources described by opts, and where each setting came
+// from, if it is valid.
+func read(opts Options) (*loaded, error) {
+	opts = opts.withDefaults()
+	secs := registered()
+	all := settings()
+	for _, sec := range secs {
+		all = append(all, sec.fields()...)
+	}
+	values := map[string]map[string]any{} // By section, then setting
+	origins := map[string]Origin{}
+	for _, s := range all {
+		origins[s.key()] = Origin{Layer: FromDefault}
+	}
+	set := func(s setting, value any, origin Origin) {
+		if values[s.section] == nil {
+			values[s.section] = map[string]any{}
+		}
+		values[s.section][s.name] = value
+		origins[s.key()] = origin
 	}
 
-	// unmarshal the configuration file into the configuration object
-	err = viper.Unmarshal(&Configuration)
+	if opts.File == "" && opts.Flags != nil {
+		if f := opts.Flags.Lookup("config"); f != nil && f.Changed {
+			opts.File = f.Value.String()
+		}
+	}
+	files, err := opts.files()
 	if err != nil {
-		// handle the error
-		panic(err)
-	}
-
-	// set the default values for the beep duration and frequency
-	Configuration.DefaultBeepDuration = beep.DefaultDuration
-	Configuration.DefaultBeepFrequency = beep.DefaultFreq
-	// set the valid baud rates
-	Configuration.ValidBaudRates = []int{0, 300, 1200, 2400, 4800, 9600, 19200, 38400, 57600, 115200}
-	// set the valid file name characters
-	Configuration.ValidFileNameCharacters = []string{" ", "-", "_", ".", "(", ")", "[", "]", "!", "1", "2", "3", "4", "5", "6", "7", "8", "9", "0", "a", "b",
-		"c", "d", "e", "f", "g", "h", "i", "j", "k", "l",
-		"m", "n", "o", "p", "q", "r", "s", "t", "u", "v",
-		"w", "x", "y", "z", "A", "B", "C", "D", "E", "F",
-		"G", "H", "I", "J", "K", "L", "M", "N", "O", "P",
-		"Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z"}
-	// set the default baud rate
-	//Configuration.DefaultBaud = 9600
-	// set the default beep duration and frequency
-	//Configuration.DefaultBeepDuration = beep.DefaultDuration
-	//Configuration.DefaultBeepFrequency = beep.DefaultFreq
-	// set the default Plex port
-	//Configuration.PlexPort = strconv.Itoa(Configuration.plexPortInt)
+		return nil, err
+	}
+	for _, f := range files {
+		read, err := readFile(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range all {
+			if value, ok := s.lookup(read); ok {
+				set(s, value, f)
+			}
+		}
+	}
+	for _, s := range all {
+		env := s.env(opts.EnvPrefix)
+		if value, ok := os.LookupEnv(env); ok {
+			set(s, value, Origin{Layer: FromEnv, Name: env})
+		}
+		if opts.Flags != nil {
+			if f := opts.Flags.Lookup(s.flag); f != nil && f.Changed {
+				set(s, f.Value.String(), Origin{Layer: FromFlag, Name: "--" + s.flag})
+			}
+		}
+	}
+
+	l := &loaded{config: Defaults(), origins: origins}
+	if err := decode(values[""], &l.config); err != nil {
+		return nil, err
+	}
+	problems := []error{l.config.Validate()}
+	for _, sec := range secs {
+		v, err := sec.decode(values[sec.name()])
+		problems = append(problems, err)
+		l.sections = append(l.sections, sectionValue{sec, v})
+	}
+	if err := errors.Join(problems...); err != nil {
+		return nil, err
+	}
+	l.copyAliases()
+	return l, nil
+}
+
+// copyAliases sets the deprecated fields of Config tagged with an alias, such as
+// DefaultRandom.PortMin, from the section setting they stand for, if that section is registered.
+func (l *loaded) copyAliases() {
+	cfg := reflect.ValueOf(&l.config).Elem()
+	for i := 0; i < cfg.NumField(); i++ {
+		namespace, name, ok := strings.Cut(cfg.Type().Field(i).Tag.Get("alias"), ".")
+		if !ok {
+			continue
+		}
+		for _, sv := range l.sections {
+			if !strings.EqualFold(sv.section.name(), namespace) {
+				continue
+			}
+			if f := reflect.ValueOf(sv.value).FieldByName(name); f.IsValid() && f.Type() == cfg.Field(i).Type() {
+				cfg.Field(i).Set(f)
+			}
+		}
+	}
+}
+
+// decode sets the fields of target, a pointer to a struct, from values keyed by setting name.
+func decode(values map[string]any, target any) error {
+	v := viper.New()
+	if err := v.MergeConfigMap(values); err != nil {
+		return errs.ErrConfigRead.Wrap(err)
+	}
+	if err := v.Unmarshal(target); err != nil {
+		return errs.ErrConfigRead.Wrap(err)
+	}
+	return nil
+}
+
+// apply makes the loaded configuration active and tells the subscribers.
+func (l *loaded) apply() {
+	for _, sv := range l.sections {
+		sv.section.store(sv.value)
+	}
+	previous := state().config
+	active.Store(l)
+	notify(Change{Previous: previous, Current: l.config})
+}
+
+// files returns the configuration files to read, lowest precedence first.
+func (o Options) files() ([]Origin, error) {
+	var files []Origin
+	if path, ok := o.find(o.SystemPaths); ok {
+		files = append(files, Origin{Layer: FromSystemFile, Name: path})
+	}
+	if path, ok := o.find(o.UserPaths); ok {
+		files = append(files, Origin{Layer: FromUserFile, Name: path})
+	}
+	if o.File != "" {
+		if _, err := os.Stat(o.File); err != nil {
+			return nil, errs.ErrConfigNotFound.With(o.File).Wrap(err)
+		}
+		files = append(files, Origin{Layer: FromFile, Name: o.File})
+	} else if path, ok := o.find(o.Paths); ok {
+		files = append(files, Origin{Layer: FromFile, Name: path})
+	}
+	if o.Required && len(files) == 0 {
+		searched := append(append(append([]string{}, o.SystemPaths...), o.UserPaths...), o.Paths...)
+		return nil, errs.ErrConfigNotFound.With(o.Name + " in " + strings.Join(searched, ", "))
+	}
+	return files, nil
+}
+
+// find returns the first configuration file in the directories, trying each format in turn.
+func (o Options) find(dirs []string) (string, bool) {
+	types := formats
+	if o.Type != "" {
+		types = []string{o.Type}
+	}
+	for _, dir := range dirs {
+		for _, ext := range types {
+			path := filepath.Join(dir, o.Name+"."+ext)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, true
+			}
+		}
+	}
+	return "", false
+}
+
+// formats are the extensions of the configuration file formats, in the order they are tried.
+var formats = []string{"env", "yaml", "yml", "toml", "json"}
+
+// readFile returns the settings in a configuration file, keyed by their names in lower case.
+func readFile(path string) (map[string]any, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, errs.ErrConfigRead.Wrap(&fs.PathError{Op: "read", Path: path, Err: err})
+	}
+	return v.AllSettings(), nil
 }
 
 // split splits a string by the given separator.
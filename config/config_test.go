@@ -0,0 +1,238 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	errs "github.com/mt1976/crt/errors"
+	pflag "github.com/spf13/pflag"
+)
+
+// reset goes back to the default configuration.
+func reset() {
+	active.Store(nil)
+}
+
+func Test_Defaults(t *testing.T) {
+	if err := Defaults().Validate(); err != nil {
+		t.Errorf("Defaults().Validate() = %v, want nil", err)
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(c *Config)
+	}{
+		{"Title length", func(c *Config) { c.TitleLength = 0 }},
+		{"Empty date format", func(c *Config) { c.ApplicationDateFormatShort = "" }},
+		{"Not a layout", func(c *Config) { c.ApplicationTimeFormat = "hh:mm" }},
+		{"Baud rate", func(c *Config) { c.DefaultBaud = 1000 }},
+		{"Key binding without a key", func(c *Config) { c.KeyBindings = "Quit:" }},
+		{"Key binding with a space", func(c *Config) { c.KeyBindings = "Quit:Q X" }},
+		{"Currency", func(c *Config) { c.Currency = "pounds" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Defaults()
+			tt.change(&c)
+			if err := c.Validate(); !errors.Is(err, errs.ErrInvalidConfig) {
+				t.Errorf("Validate() = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+}
+
+func Test_Load(t *testing.T) {
+	defer reset()
+	dir := t.TempDir()
+
+	err := Load(Options{Name: "terminal", Type: "env", Paths: []string{dir}, UserPaths: []string{}, SystemPaths: []string{}, Required: true})
+	if !errors.Is(err, errs.ErrConfigNotFound) {
+		t.Errorf("Load() with no file = %v, want ErrConfigNotFound", err)
+	}
+	if err := Load(Options{Paths: []string{dir}, UserPaths: []string{}, SystemPaths: []string{}}); err != nil {
+		t.Errorf("Load() with no file, not required = %v, want nil", err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "terminal.env"), []byte("TitleLength=60\n"), 0o644)
+	if err := Load(Options{Paths: []string{dir}, UserPaths: []string{}, SystemPaths: []string{}, Required: true}); err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+	if Current().TitleLength != 60 || Current().MaxContentRows != 18 {
+		t.Errorf("Load() TitleLength = %v, MaxContentRows = %v, want 60 and the default 18", Current().TitleLength, Current().MaxContentRows)
+	}
+
+	os.WriteFile(filepath.Join(dir, "terminal.env"), []byte("TitleLength=0\n"), 0o644)
+	if err := Load(Options{Paths: []string{dir}, UserPaths: []string{}, SystemPaths: []string{}}); !errors.Is(err, errs.ErrInvalidConfig) {
+		t.Errorf("Load() with a bad value = %v, want ErrInvalidConfig", err)
+	}
+	if Current().TitleLength != 60 {
+		t.Errorf("Load() with a bad value changed the configuration")
+	}
+}
+
+func Test_Precedence(t *testing.T) {
+	defer reset()
+	system, user, app := t.TempDir(), t.TempDir(), t.TempDir()
+	os.WriteFile(filepath.Join(system, "terminal.yaml"), []byte("TitleLength: 50\nMaxNoItems: 10\nMaxContentRows: 12\nDelay: 1.5\n"), 0o644)
+	os.WriteFile(filepath.Join(user, "terminal.json"), []byte(`{"MaxNoItems": 11, "MaxContentRows": 13, "Delay": 2}`), 0o644)
+	os.WriteFile(filepath.Join(app, "terminal.toml"), []byte("MaxContentRows = 14\nDelay = 2.5\n"), 0o644)
+	t.Setenv("TEST_DELAY", "3")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	if err := BindFlags(flags); err != nil {
+		t.Fatal(err)
+	}
+	if err := flags.Parse([]string{"--theme", "amber"}); err != nil {
+		t.Fatal(err)
+	}
+	opts := Options{Paths: []string{app}, UserPaths: []string{user}, SystemPaths: []string{system}, EnvPrefix: "TEST", Flags: flags}
+	if err := Load(opts); err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		got   any
+		want  any
+		layer Layer
+	}{
+		{"TitleLength", Current().TitleLength, 50, FromSystemFile},
+		{"MaxNoItems", Current().MaxNoItems, 11, FromUserFile},
+		{"MaxContentRows", Current().MaxContentRows, 14, FromFile},
+		{"Delay", Current().Delay, 3.0, FromEnv},
+		{"Theme", Current().Theme, "amber", FromFlag},
+		{"ApplicationTimeFormat", Current().ApplicationTimeFormat, "15:04", FromDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%v = %v, want %v", tt.name, tt.got, tt.want)
+			}
+			if got := Source(tt.name).Layer; got != tt.layer {
+				t.Errorf("Source(%v) = %v, want %v", tt.name, Source(tt.name), tt.layer)
+			}
+		})
+	}
+
+	var out strings.Builder
+	Show(&out)
+	if !strings.Contains(out.String(), "env TEST_DELAY") {
+		t.Errorf("Show() does not give the source of Delay:\n%v", out.String())
+	}
+}
+
+func Test_flagName(t *testing.T) {
+	tests := map[string]string{
+		"TitleLength":                "title-length",
+		"DefaultRandomIPMin":         "default-random-ip-min",
+		"ApplicationDateFormatShort": "application-date-format-short",
+		"Baud":                       "baud",
+	}
+	for name, want := range tests {
+		if got := flagName(name); got != want {
+			t.Errorf("flagName(%v) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func Test_Watch(t *testing.T) {
+	defer reset()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "terminal.env")
+	os.WriteFile(path, []byte("TitleLength=50\n"), 0o644)
+	opts := Options{Paths: []string{dir}, UserPaths: []string{}, SystemPaths: []string{}}
+	if err := Load(opts); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := make(chan Change, 4)
+	cancel := OnChange(func(c Change) { changes <- c })
+	defer cancel()
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	if err := Watch(ctx, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	os.WriteFile(path, []byte("TitleLength=0\n"), 0o644)
+	select {
+	case c := <-changes:
+		if !errors.Is(c.Err, errs.ErrInvalidConfig) || c.Current.TitleLength != 50 {
+			t.Errorf("bad reload = %v, TitleLength %v, want ErrInvalidConfig and 50", c.Err, c.Current.TitleLength)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change after writing a bad value")
+	}
+
+	os.WriteFile(path, []byte("TitleLength=60\n"), 0o644)
+	select {
+	case c := <-changes:
+		if c.Err != nil || c.Previous.TitleLength != 50 || c.Current.TitleLength != 60 {
+			t.Errorf("reload = %+v, want TitleLength from 50 to 60", c.Err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change after writing the file")
+	}
+}
+
+func Test_Section(t *testing.T) {
+	type plex struct {
+		URI  string
+		Port int
+	}
+	defer reset()
+	validate := func(p plex) error {
+		if p.Port < 1 {
+			return errors.New("Port must be positive")
+		}
+		return nil
+	}
+	section := Register("Plex", plex{Port: 32400}, validate)
+	defer func() {
+		sectionsLock.Lock()
+		sections = nil
+		sectionsLock.Unlock()
+	}()
+	opts := func(dir string) Options {
+		return Options{Paths: []string{dir}, UserPaths: []string{}, SystemPaths: []string{}}
+	}
+
+	yamlDir, envDir := t.TempDir(), t.TempDir()
+	os.WriteFile(filepath.Join(yamlDir, "terminal.yaml"), []byte("TitleLength: 50\nPlex:\n  URI: http://yaml\n"), 0o644)
+	os.WriteFile(filepath.Join(envDir, "terminal.env"), []byte("PlexURI=http://env\nPLEX_PORT=1234\n"), 0o644)
+
+	if err := Load(opts(yamlDir)); err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+	if got := section.Get(); got.URI != "http://yaml" || got.Port != 32400 || Source("plex.uri").Layer != FromFile {
+		t.Errorf("Get() from YAML = %+v, source %v", got, Source("plex.uri"))
+	}
+
+	if err := Load(opts(envDir)); err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+	if got := section.Get(); got.URI != "http://env" || got.Port != 1234 {
+		t.Errorf("Get() from env file = %+v", got)
+	}
+
+	t.Setenv("CRT_PLEX_PORT", "0")
+	if err := Load(opts(envDir)); !errors.Is(err, errs.ErrInvalidConfig) {
+		t.Errorf("Load() with a bad section = %v, want ErrInvalidConfig", err)
+	}
+	if got := section.Get(); got.Port != 1234 {
+		t.Errorf("Get() after a bad load = %+v, want it unchanged", got)
+	}
+
+	var out strings.Builder
+	Show(&out)
+	if !strings.Contains(out.String(), "Plex.URI") {
+		t.Errorf("Show() does not list the section:\n%v", out.String())
+	}
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	errs "github.com/mt1976/crt/errors"
+)
+
+// Section is an application's own part of the configuration, a struct of type T read from the
+// settings under its namespace. In a YAML, TOML or JSON file these are nested under the namespace;
+// in an env file, the environment and flags they are named by the namespace and the setting, such as
+// Plex_URI, CRT_PLEX_URI and --plex-uri. Sections are loaded, validated, watched and shown along
+// with the library's own settings.
+type Section[T any] struct {
+	namespace string
+	defaults  T
+	validate  func(T) error
+	lock      sync.RWMutex
+	value     T
+}
+
+// section is a Section of any type.
+type section interface {
+	name() string
+	fields() []setting
+	decode(values map[string]any) (any, error)
+	store(v any)
+	defaultValue() reflect.Value
+	currentValue() reflect.Value
+}
+
+// sectionValue is the value read for a section, before it is stored.
+type sectionValue struct {
+	section section
+	value   any
+}
+
+var (
+	sectionsLock sync.RWMutex
+	sections     []section
+)
+
+// Register adds a section of the configuration for an application, under the given namespace,
+// replacing any section already registered with the same namespace. T must be a struct; its fields
+// are named as in Config, by their mapstructure tags or field names. The section holds defaults
+// until the configuration is next loaded, when validate, if it is not nil, checks the values read.
+//
+//	type Plex struct {
+//		URI   string
+//		Port  int
+//		Token string
+//	}
+//
+//	var plex = config.Register("Plex", Plex{Port: 32400}, nil)
+//
+//	...
+//	uri := plex.Get().URI
+func Register[T any](namespace string, defaults T, validate func(T) error) *Section[T] {
+	s := &Section[T]{namespace: namespace, defaults: defaults, validate: validate, value: defaults}
+	sectionsLock.Lock()
+	defer sectionsLock.Unlock()
+	for i, existing := range sections {
+		if strings.EqualFold(existing.name(), namespace) {
+			sections[i] = s
+			return s
+		}
+	}
+	sections = append(sections, s)
+	return s
+}
+
+// Unregister removes the section, if it is still registered, so it is no longer loaded, bound to
+// flags or shown. Tests use it to undo a Register.
+func (s *Section[T]) Unregister() {
+	sectionsLock.Lock()
+	defer sectionsLock.Unlock()
+	for i, existing := range sections {
+		if existing == section(s) {
+			sections = append(sections[:i], sections[i+1:]...)
+			return
+		}
+	}
+}
+
+// registered returns the registered sections, in the order they were registered.
+func registered() []section {
+	sectionsLock.RLock()
+	defer sectionsLock.RUnlock()
+	return append([]section(nil), sections...)
+}
+
+// Get returns the section's values, as last loaded.
+func (s *Section[T]) Get() T {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.value
+}
+
+// Namespace returns the namespace the section is read from.
+func (s *Section[T]) Namespace() string {
+	return s.namespace
+}
+
+func (s *Section[T]) name() string {
+	return s.namespace
+}
+
+func (s *Section[T]) fields() []setting {
+	return fieldsOf(reflect.TypeOf(s.defaults), s.namespace)
+}
+
+// decode returns the section's values read from the settings, over its defaults, if they are valid.
+func (s *Section[T]) decode(values map[string]any) (any, error) {
+	v := s.defaults
+	if err := decode(values, &v); err != nil {
+		return nil, err
+	}
+	if s.validate != nil {
+		if err := s.validate(v); err != nil {
+			return nil, errs.ErrInvalidConfig.With(s.namespace, "is not valid").Wrap(err)
+		}
+	}
+	return v, nil
+}
+
+func (s *Section[T]) store(v any) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.value = v.(T)
+}
+
+func (s *Section[T]) defaultValue() reflect.Value {
+	return reflect.ValueOf(s.defaults)
+}
+
+func (s *Section[T]) currentValue() reflect.Value {
+	return reflect.ValueOf(s.Get())
+}
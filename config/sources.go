@@ -0,0 +1,254 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+
+	errs "github.com/mt1976/crt/errors"
+	pflag "github.com/spf13/pflag"
+)
+
+// Layer identifies where a setting's value came from.
+type Layer int
+
+const (
+	FromDefault    Layer = iota // The built in default
+	FromSystemFile              // The system configuration file
+	FromUserFile                // The user configuration file
+	FromFile                    // The application configuration file
+	FromEnv                     // An environment variable
+	FromFlag                    // A command line flag
+)
+
+// Origin is where a setting's value came from: the layer, and the file, environment variable or
+// flag that set it.
+type Origin struct {
+	Layer Layer
+	Name  string
+}
+
+// String describes the origin, such as "env CRT_DELAY".
+func (o Origin) String() string {
+	switch o.Layer {
+	case FromSystemFile:
+		return "system file " + o.Name
+	case FromUserFile:
+		return "user file " + o.Name
+	case FromFile:
+		return "file " + o.Name
+	case FromEnv:
+		return "env " + o.Name
+	case FromFlag:
+		return "flag " + o.Name
+	}
+	return "default"
+}
+
+// Source returns where the named setting's value came from. Settings in a section are named by the
+// section and the setting, such as Plex.URI.
+func Source(name string) Origin {
+	if s, ok := lookupSetting(name); ok {
+		return state().origins[s.key()]
+	}
+	return Origin{}
+}
+
+// setting is a configuration setting that can be given in a file, the environment or a flag.
+type setting struct {
+	section string // The namespace of the section the setting belongs to, empty for Config
+	name    string // The name, as used in files, such as TitleLength
+	flag    string // The command line flag, such as title-length
+	field   int    // The index of the field in its struct
+	kind    reflect.Kind
+}
+
+// key returns the name of the setting, with its section if it has one, such as Plex.URI.
+func (s setting) key() string {
+	if s.section == "" {
+		return s.name
+	}
+	return s.section + "." + s.name
+}
+
+// env returns the environment variable that gives the setting, such as CRT_PLEX_URI.
+func (s setting) env(prefix string) string {
+	return strings.ToUpper(prefix + "_" + strings.ReplaceAll(s.key(), ".", "_"))
+}
+
+// lookup returns the setting's value from the settings read from a file, keyed in lower case. A
+// setting in a section is either nested under the section's namespace, as in a YAML file, or given
+// by the namespace and the name together, such as PLEX_URI or PlexURI in an env file.
+func (s setting) lookup(values map[string]any) (any, bool) {
+	name := strings.ToLower(s.name)
+	if s.section == "" {
+		v, ok := values[name]
+		return v, ok
+	}
+	ns := strings.ToLower(s.section)
+	if nested, ok := values[ns].(map[string]any); ok {
+		if v, ok := nested[name]; ok {
+			return v, true
+		}
+	}
+	for _, key := range []string{ns + "_" + name, ns + name} {
+		if v, ok := values[key]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// settings returns the settings of Config with a single value, in the order they are declared.
+func settings() []setting {
+	return fieldsOf(reflect.TypeOf(Config{}), "")
+}
+
+// allSettings returns the settings of Config followed by those of each registered section.
+func allSettings() []setting {
+	all := settings()
+	for _, sec := range registered() {
+		all = append(all, sec.fields()...)
+	}
+	return all
+}
+
+// fieldsOf returns the settings for the fields of a struct with a single value.
+func fieldsOf(t reflect.Type, section string) []setting {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var out []setting
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.String, reflect.Int, reflect.Float64, reflect.Bool:
+		default:
+			continue
+		}
+		name := f.Tag.Get("mapstructure")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		flag := flagName(name)
+		if section != "" {
+			flag = flagName(section) + "-" + flag
+		}
+		out = append(out, setting{section: section, name: name, flag: flag, field: i, kind: f.Type.Kind()})
+	}
+	return out
+}
+
+// lookupSetting returns the setting with the given name, such as TitleLength or Plex.URI, ignoring
+// case.
+func lookupSetting(name string) (setting, bool) {
+	for _, s := range allSettings() {
+		if strings.EqualFold(s.key(), name) {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+// flagName converts a setting name such as DefaultRandomIPMin to a flag name, default-random-ip-min.
+func flagName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteRune('-')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// BindFlags adds a flag for every setting to fs, such as --title-length, along with --config, to
+// name the configuration file, and --show-config. Pass fs to Load in Options.Flags once it has been
+// parsed; only the flags that were given override other sources.
+//
+//	flags := pflag.NewFlagSet("app", pflag.ExitOnError)
+//	if err := config.BindFlags(flags); err != nil {
+//		log.Fatal(err)
+//	}
+//	flags.Parse(os.Args[1:])
+//	opts := config.DefaultOptions()
+//	opts.Flags = flags
+//	if err := config.Load(opts); err != nil {
+//		log.Fatal(err)
+//	}
+//	if show, _ := flags.GetBool("show-config"); show {
+//		config.Show(os.Stdout)
+//		os.Exit(0)
+//	}
+func BindFlags(fs *pflag.FlagSet) error {
+	// pflag panics if a flag is added twice, so look for a name used twice first
+	names := map[string]string{"config": "--config", "show-config": "--show-config"}
+	for _, s := range allSettings() {
+		if other, ok := names[s.flag]; ok {
+			return errs.ErrDuplicateSetting.With(s.flag, other, s.key())
+		}
+		if fs.Lookup(s.flag) != nil {
+			return errs.ErrDuplicateSetting.With(s.flag, "a flag already added", s.key())
+		}
+		names[s.flag] = s.key()
+	}
+	bind := func(settings []setting, defaults reflect.Value) {
+		for _, s := range settings {
+			usage := "the " + s.key() + " setting"
+			value := defaults.Field(s.field)
+			switch s.kind {
+			case reflect.String:
+				fs.String(s.flag, value.String(), usage)
+			case reflect.Int:
+				fs.Int(s.flag, int(value.Int()), usage)
+			case reflect.Float64:
+				fs.Float64(s.flag, value.Float(), usage)
+			case reflect.Bool:
+				fs.Bool(s.flag, value.Bool(), usage)
+			}
+		}
+	}
+	bind(settings(), reflect.ValueOf(Defaults()))
+	for _, sec := range registered() {
+		bind(sec.fields(), sec.defaultValue())
+	}
+	fs.String("config", "", "the configuration file to read")
+	fs.Bool("show-config", false, "show the configuration and where each setting came from")
+	return nil
+}
+
+// Show writes every setting of the active configuration, including the registered sections, with
+// its value and where it came from.
+func Show(w io.Writer) error {
+	l := state()
+	show := func(settings []setting, current reflect.Value) error {
+		for _, s := range settings {
+			if _, err := fmt.Fprintf(w, "%-28s %-24v %v\n", s.key(), current.Field(s.field).Interface(), l.origins[s.key()]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := show(settings(), reflect.ValueOf(l.config)); err != nil {
+		return err
+	}
+	for _, sec := range registered() {
+		if err := show(sec.fields(), sec.currentValue()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
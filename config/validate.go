@@ -0,0 +1,141 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	errs "github.com/mt1976/crt/errors"
+)
+
+var (
+	checksLock sync.RWMutex
+	checks     []check
+)
+
+// check is a function added by AddCheck, with the setting it checks.
+type check struct {
+	setting string
+	fn      func(Config) error
+}
+
+// AddCheck adds a function that Validate calls to check a setting that names something defined in
+// another package, such as a box style, a theme or a file only that package can read. The package
+// adds its checks when it is initialised, so they are made whenever it is in use.
+func AddCheck(setting string, fn func(Config) error) {
+	checksLock.Lock()
+	defer checksLock.Unlock()
+	checks = append(checks, check{setting, fn})
+}
+
+// Validate checks that every setting is in range and that the date and time formats are layouts
+// Go can format with, along with the settings checked by the functions added with AddCheck. It
+// returns all the problems found, each wrapping ErrInvalidConfig.
+func (c Config) Validate() error {
+	var problems []error
+	fail := func(setting, format string, args ...any) {
+		problems = append(problems, errs.ErrInvalidConfig.With(setting, fmt.Sprintf(format, args...)))
+	}
+	atLeast := func(setting string, value, min int) {
+		if value < min {
+			fail(setting, "must be at least %v, is %v", min, value)
+		}
+	}
+	between := func(setting string, value, min, max int) {
+		if value < min || value > max {
+			fail(setting, "must be between %v and %v, is %v", min, max, value)
+		}
+	}
+	layout := func(setting, value string) {
+		if !isLayout(value) {
+			fail(setting, "is not a date or time layout, such as 02/01/06 or 15:04, is %q", value)
+		}
+	}
+
+	layout("ApplicationDateFormat", c.ApplicationDateFormat)
+	layout("ApplicationDateFormatShort", c.ApplicationDateFormatShort)
+	layout("ApplicationTimeFormat", c.ApplicationTimeFormat)
+	if c.Delay < 0 {
+		fail("Delay", "must not be negative, is %v", c.Delay)
+	}
+	if c.DefaultErrorDelay < 0 {
+		fail("DefaultErrorDelay", "must not be negative, is %v", c.DefaultErrorDelay)
+	}
+	atLeast("MaxContentRows", c.MaxContentRows, 1)
+	atLeast("MaxNoItems", c.MaxNoItems, 1)
+	between("TitleLength", c.TitleLength, 1, 200)
+	if !slices.Contains(c.ValidBaudRates, c.DefaultBaud) {
+		fail("DefaultBaud", "must be one of %v, is %v", c.ValidBaudRates, c.DefaultBaud)
+	}
+	if c.Baud > 0 && !slices.Contains(c.ValidBaudRates, c.Baud) {
+		fail("Baud", "must be one of %v, is %v", c.ValidBaudRates, c.Baud)
+	}
+	if c.PageDumpActive && c.PageDumpPath == "" {
+		fail("PageDumpPath", "must be set when PageDumpActive is true")
+	}
+	if c.Currency != "" && !isCurrencyCode(c.Currency) {
+		fail("Currency", "must be a three letter ISO 4217 code, such as GBP, is %q", c.Currency)
+	}
+	if _, err := ParseKeyBindings(c.KeyBindings); err != nil {
+		problems = append(problems, errs.ErrInvalidConfig.With("KeyBindings", "is not valid").Wrap(err))
+	}
+	checksLock.RLock()
+	defer checksLock.RUnlock()
+	for _, check := range checks {
+		if err := check.fn(c); err != nil {
+			problems = append(problems, errs.ErrInvalidConfig.With(check.setting, "is not valid").Wrap(err))
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// ParseKeyBindings reads key bindings such as "Quit:X, Forward:N", returning the key given for each
+// action by its name in lower case. It only checks that each binding is an action and a key, without
+// spaces; the page/actions package checks that the actions can be rebound and do not share keys.
+func ParseKeyBindings(bindings string) (map[string]string, error) {
+	keys := map[string]string{}
+	for _, binding := range strings.Split(bindings, ",") {
+		binding = strings.TrimSpace(binding)
+		if binding == "" {
+			continue
+		}
+		name, key, ok := strings.Cut(binding, ":")
+		name, key = strings.ToLower(strings.TrimSpace(name)), strings.TrimSpace(key)
+		if !ok || name == "" || key == "" || strings.IndexFunc(key, unicode.IsSpace) >= 0 {
+			return nil, errs.ErrInvalidKeyBinding.With(binding)
+		}
+		keys[name] = key
+	}
+	return keys, nil
+}
+
+// isLayout returns true if s is a time layout: formatting a time with it and parsing the result
+// gives no error, and it contains at least one element that changes with the time.
+func isLayout(s string) bool {
+	if s == "" {
+		return false
+	}
+	ref := time.Date(2024, time.December, 25, 13, 45, 30, 0, time.UTC)
+	formatted := ref.Format(s)
+	if formatted == s {
+		return false
+	}
+	_, err := time.Parse(s, formatted)
+	return err == nil
+}
+
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	errs "github.com/mt1976/crt/errors"
+)
+
+// Change describes a reload of the configuration. If the reload failed, Err says why and the
+// configuration is unchanged.
+type Change struct {
+	Previous Config
+	Current  Config
+	Err      error
+}
+
+var (
+	subscribersLock sync.Mutex
+	subscribers     = map[int]func(Change){}
+	nextSubscriber  int
+)
+
+// OnChange calls fn each time the configuration is loaded, or fails to reload while being watched.
+// It returns a function that stops the calls. fn is called on the goroutine that loaded the
+// configuration, which for Watch is its own, so it should hand any change to the screen over to the
+// goroutine reading input.
+func OnChange(fn func(Change)) (cancel func()) {
+	subscribersLock.Lock()
+	defer subscribersLock.Unlock()
+	id := nextSubscriber
+	nextSubscriber++
+	subscribers[id] = fn
+	return func() {
+		subscribersLock.Lock()
+		defer subscribersLock.Unlock()
+		delete(subscribers, id)
+	}
+}
+
+// notify calls every subscriber with the change, in the order they subscribed.
+func notify(c Change) {
+	subscribersLock.Lock()
+	ids := make([]int, 0, len(subscribers))
+	for id := range subscribers {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	fns := make([]func(Change), 0, len(ids))
+	for _, id := range ids {
+		fns = append(fns, subscribers[id])
+	}
+	subscribersLock.Unlock()
+	for _, fn := range fns {
+		fn(c)
+	}
+}
+
+// settle is how long Watch waits for a file to stop changing before reloading, as editors often
+// write a file in several steps.
+const settle = 200 * time.Millisecond
+
+// Watch reloads the configuration with Load whenever one of its files, or the theme file it names,
+// is written, until ctx is cancelled. Subscribers added with OnChange are told of each reload. A file
+// that fails to load or validate leaves the configuration as it was.
+func Watch(ctx context.Context, opts Options) error {
+	opts = opts.withDefaults()
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errs.ErrConfigWatch.Wrap(err)
+	}
+	watched := 0
+	for _, dir := range opts.watchedDirs() {
+		if watcher.Add(dir) == nil {
+			watched++
+		}
+	}
+	if watched == 0 {
+		watcher.Close()
+		return errs.ErrConfigWatch.Wrap(errors.New("none of the configuration directories exist"))
+	}
+
+	current := *Current()
+	go func() {
+		defer watcher.Close()
+		timer := time.NewTimer(settle)
+		timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Has(fsnotify.Chmod) || !opts.watches(event.Name, current.ThemeFile) {
+					continue
+				}
+				timer.Reset(settle)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				notify(Change{Previous: current, Current: current, Err: errs.ErrConfigWatch.Wrap(err)})
+			case <-timer.C:
+				l, err := read(opts)
+				if err != nil {
+					notify(Change{Previous: current, Current: current, Err: err})
+					continue
+				}
+				if l.config.ThemeFile != "" {
+					watcher.Add(filepath.Dir(l.config.ThemeFile))
+				}
+				current = l.config
+				l.apply()
+			}
+		}
+	}()
+	return nil
+}
+
+// watchedDirs returns the directories that hold, or could hold, the configuration and theme files.
+// Directories are watched rather than files so that files replaced by an editor are still followed.
+func (o Options) watchedDirs() []string {
+	dirs := append(append(append([]string{}, o.SystemPaths...), o.UserPaths...), o.Paths...)
+	if o.File != "" {
+		dirs = append(dirs, filepath.Dir(o.File))
+	}
+	if themeFile := Current().ThemeFile; themeFile != "" {
+		dirs = append(dirs, filepath.Dir(themeFile))
+	}
+	var out []string
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() && !slices.Contains(out, dir) {
+			out = append(out, dir)
+		}
+	}
+	return out
+}
+
+// watches returns true if a change to the file at path, which may be the theme file, should reload
+// the configuration.
+func (o Options) watches(path, themeFile string) bool {
+	if o.File != "" && samePath(path, o.File) {
+		return true
+	}
+	if themeFile != "" && samePath(path, themeFile) {
+		return true
+	}
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	if strings.TrimSuffix(filepath.Base(path), "."+ext) != o.Name {
+		return false
+	}
+	if o.Type != "" {
+		return ext == o.Type
+	}
+	return slices.Contains(formats, ext)
+}
+
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
@@ -0,0 +1,72 @@
+package dashboard
+
+import (
+	"context"
+	"time"
+
+	page "github.com/mt1976/crt/page"
+)
+
+// Grid places widgets in rows of equally sized, titled cells filling a page's text area.
+type Grid struct {
+	page    *page.Page
+	columns int
+	widgets []Widget
+}
+
+// NewGrid returns an empty grid with the given number of columns, shown on the page.
+func NewGrid(p *page.Page, columns int) *Grid {
+	g := &Grid{page: p, columns: max(columns, 1)}
+	g.layout()
+	return g
+}
+
+// Add places widgets in the next free cells of the grid, filling each row before starting the next.
+func (g *Grid) Add(widgets ...Widget) {
+	g.widgets = append(g.widgets, widgets...)
+	g.layout()
+}
+
+// layout rebuilds the page's panels to hold the grid's widgets.
+func (g *Grid) layout() {
+	var rows []*page.Panel
+	for start := 0; start < len(g.widgets); start += g.columns {
+		var cells []*page.Panel
+		for i := start; i < start+g.columns; i++ {
+			if i >= len(g.widgets) {
+				cells = append(cells, page.NewPanel("", page.Ratio(1)))
+				continue
+			}
+			cell := page.NewPanel(g.widgets[i].Title(), page.Ratio(1))
+			cell.SetSource(g.widgets[i].Render)
+			cells = append(cells, cell)
+		}
+		rows = append(rows, page.NewColumns(page.Ratio(1), cells...))
+	}
+	g.page.SetLayout(page.NewRows(page.Ratio(1), rows...))
+}
+
+// Refresh redraws the grid's widgets on the page, leaving the cursor where it was.
+func (g *Grid) Refresh() {
+	g.page.RefreshLayout()
+}
+
+// Start calls update and then redraws the grid every interval, until the context is cancelled.
+// update may be nil if the widgets are changed elsewhere.
+func (g *Grid) Start(ctx context.Context, interval time.Duration, update func()) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if update != nil {
+					update()
+				}
+				g.Refresh()
+			}
+		}
+	}()
+}
@@ -0,0 +1,271 @@
+package dashboard
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	hlpr "github.com/mt1976/crt/helpers"
+	symb "github.com/mt1976/crt/strings/symbols"
+	wdth "github.com/mt1976/crt/strings/width"
+)
+
+// styles colours the widgets.
+var styles = hlpr.InitStyles()
+
+// Widget is something that can be shown in a cell of a Grid.
+type Widget interface {
+	// Title is shown in the border of the widget's cell.
+	Title() string
+	// Render returns the lines that show the widget in the given width and height.
+	Render(width, height int) []string
+}
+
+// partials are the eighths of a block, used to draw the end of a bar more precisely.
+var partials = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// bar returns a bar of the given width, filled in proportion to fraction.
+func bar(fraction float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	fraction = math.Max(0, math.Min(1, fraction))
+	eighths := int(math.Round(fraction * float64(width*8)))
+	full, part := eighths/8, eighths%8
+	out := strings.Repeat(symb.GaugeFull.Symbol(), full)
+	used := full
+	if part > 0 {
+		out += partials[part]
+		used++
+	}
+	return out + strings.Repeat(symb.GaugeEmpty.Symbol(), width-used)
+}
+
+// Gauge is a horizontal bar showing a value out of a maximum, such as disk space used.
+type Gauge struct {
+	lock   sync.Mutex
+	title  string
+	value  float64
+	max    float64
+	Format func(v float64) string // Formats the value and maximum, such as strg.HumanDiskSize
+}
+
+// NewGauge returns a gauge showing value out of max.
+func NewGauge(title string, value, max float64) *Gauge {
+	return &Gauge{title: title, value: value, max: max, Format: func(v float64) string { return fmt.Sprintf("%.0f", v) }}
+}
+
+// Set updates the value and maximum shown by the gauge.
+func (g *Gauge) Set(value, max float64) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.value, g.max = value, max
+}
+
+// Title returns the title of the gauge.
+func (g *Gauge) Title() string {
+	return g.title
+}
+
+// Render returns the gauge as a bar followed by the value and maximum.
+func (g *Gauge) Render(width, height int) []string {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	fraction := 0.0
+	if g.max > 0 {
+		fraction = g.value / g.max
+	}
+	label := fmt.Sprintf(" %v/%v", g.Format(g.value), g.Format(g.max))
+	return []string{bar(fraction, width-wdth.Of(label)) + label}
+}
+
+// Meter shows a percentage, coloured by how close it is to its warning and failure thresholds.
+type Meter struct {
+	lock    sync.Mutex
+	title   string
+	percent float64
+	warn    float64
+	fail    float64
+}
+
+// NewMeter returns a meter showing percent, which turns yellow at 75% and red at 90%.
+func NewMeter(title string, percent float64) *Meter {
+	return &Meter{title: title, percent: percent, warn: 75, fail: 90}
+}
+
+// SetThresholds sets the percentages at which the meter turns yellow and red.
+func (m *Meter) SetThresholds(warn, fail float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.warn, m.fail = warn, fail
+}
+
+// Set updates the percentage shown by the meter.
+func (m *Meter) Set(percent float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.percent = percent
+}
+
+// Title returns the title of the meter.
+func (m *Meter) Title() string {
+	return m.title
+}
+
+// Render returns the meter as a coloured bar followed by the percentage.
+func (m *Meter) Render(width, height int) []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	label := fmt.Sprintf(" %5.1f%%", m.percent)
+	colour := styles.Green
+	switch {
+	case m.percent >= m.fail:
+		colour = styles.Red
+	case m.percent >= m.warn:
+		colour = styles.Yellow
+	}
+	return []string{colour(bar(m.percent/100, width-wdth.Of(label))) + label}
+}
+
+// sparks are the heights used to draw a sparkline, lowest first.
+var sparks = []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+// Sparkline shows the recent history of a value as a line of bars of varying height.
+type Sparkline struct {
+	lock   sync.Mutex
+	title  string
+	values []float64
+	limit  int
+}
+
+// NewSparkline returns a sparkline of the given values, which keeps at most 240 values as more are pushed.
+func NewSparkline(title string, values []float64) *Sparkline {
+	return &Sparkline{title: title, values: values, limit: 240}
+}
+
+// Push adds a value to the end of the sparkline, dropping the oldest if it is full.
+func (s *Sparkline) Push(value float64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.values = append(s.values, value)
+	if len(s.values) > s.limit {
+		s.values = s.values[len(s.values)-s.limit:]
+	}
+}
+
+// Set replaces the values shown by the sparkline.
+func (s *Sparkline) Set(values []float64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.values = values
+}
+
+// Title returns the title of the sparkline.
+func (s *Sparkline) Title() string {
+	return s.title
+}
+
+// Render returns the most recent values that fit in the width, scaled between their minimum and
+// maximum, with a summary line below if there is room.
+func (s *Sparkline) Render(width, height int) []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	values := s.values
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low, high = math.Min(low, v), math.Max(high, v)
+	}
+	var line strings.Builder
+	for _, v := range values {
+		level := len(sparks) - 1
+		if high > low {
+			level = int((v - low) / (high - low) * float64(len(sparks)-1))
+		}
+		line.WriteString(sparks[level])
+	}
+	lines := []string{line.String()}
+	if height > 1 {
+		lines = append(lines, fmt.Sprintf("min %.1f max %.1f last %.1f", low, high, values[len(values)-1]))
+	}
+	return lines
+}
+
+// Status is the state shown by a StatusTile.
+type Status int
+
+const (
+	OK Status = iota
+	Warn
+	Fail
+)
+
+// String returns the name of the status.
+func (s Status) String() string {
+	switch s {
+	case Warn:
+		return "WARN"
+	case Fail:
+		return "FAIL"
+	}
+	return "OK"
+}
+
+// colour returns the status's name, coloured green, yellow or red.
+func (s Status) colour() string {
+	switch s {
+	case Warn:
+		return styles.Yellow(symb.StatusDot.Symbol() + symb.Space.Symbol() + s.String())
+	case Fail:
+		return styles.Red(symb.StatusDot.Symbol() + symb.Space.Symbol() + s.String())
+	}
+	return styles.Green(symb.StatusDot.Symbol() + symb.Space.Symbol() + s.String())
+}
+
+// StatusTile shows whether something is OK, warning or failing, with a line of detail.
+type StatusTile struct {
+	lock   sync.Mutex
+	title  string
+	status Status
+	detail string
+}
+
+// NewStatusTile returns a tile showing the status and detail.
+func NewStatusTile(title string, status Status, detail string) *StatusTile {
+	return &StatusTile{title: title, status: status, detail: detail}
+}
+
+// Set updates the status and detail shown by the tile.
+func (t *StatusTile) Set(status Status, detail string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.status, t.detail = status, detail
+}
+
+// Status returns the status shown by the tile.
+func (t *StatusTile) Status() Status {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.status
+}
+
+// Title returns the title of the tile.
+func (t *StatusTile) Title() string {
+	return t.title
+}
+
+// Render returns the coloured status followed by the detail, on the same line if there is only one.
+func (t *StatusTile) Render(width, height int) []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if height < 2 {
+		return []string{t.status.colour() + symb.Space.Symbol() + t.detail}
+	}
+	return []string{t.status.colour(), t.detail}
+}
@@ -0,0 +1,53 @@
+package dashboard
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_bar(t *testing.T) {
+	tests := []struct {
+		name     string
+		fraction float64
+		width    int
+		want     string
+	}{
+		{"Empty", 0, 4, "░░░░"},
+		{"Full", 1, 4, "████"},
+		{"Half", 0.5, 4, "██░░"},
+		{"Partial block", 0.3, 4, "█▎░░"},
+		{"Over full", 1.5, 3, "███"},
+		{"No room", 0.5, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bar(tt.fraction, tt.width); got != tt.want {
+				t.Errorf("bar() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Render(t *testing.T) {
+	tests := []struct {
+		name   string
+		widget Widget
+		width  int
+		height int
+		want   []string
+	}{
+		{"Gauge", NewGauge("Disk", 50, 100), 14, 1, []string{"███▌░░░ 50/100"}},
+		{"Meter", NewMeter("CPU", 50), 12, 1, []string{"██▌░░  50.0%"}},
+		{"Sparkline", NewSparkline("Load", []float64{0, 1, 2, 3, 4, 5, 6, 7}), 8, 1, []string{"▁▂▃▄▅▆▇█"}},
+		{"Sparkline keeps the latest values", NewSparkline("Load", []float64{9, 0, 7}), 2, 2, []string{"▁█", "min 0.0 max 7.0 last 7.0"}},
+		{"Status tile", NewStatusTile("Web", Warn, "slow"), 20, 2, []string{"● WARN", "slow"}},
+		{"Status tile on one line", NewStatusTile("Web", Fail, "down"), 20, 1, []string{"● FAIL down"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.widget.Render(tt.width, tt.height); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,18 +2,14 @@ package datestimes
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
-	conf "github.com/mt1976/crt/config"
-	lang "github.com/mt1976/crt/language"
+	locl "github.com/mt1976/crt/language/locale"
 	symb "github.com/mt1976/crt/strings/symbols"
-	"github.com/xeonx/timeago"
+	wdth "github.com/mt1976/crt/strings/width"
 )
 
-var config = conf.Configuration
-
 func UnixDateToHuman(unixTime int64) string {
 	// golang date from unixTime
 	t := time.Unix(unixTime, 0)
@@ -21,19 +17,16 @@ func UnixDateToHuman(unixTime int64) string {
 	return h
 }
 
-// The function dateString returns the current date in the format "dd/mm/yy".
+// The function dateString returns the current date in the active locale's short form, such as "dd/mm/yy".
 func DateString() string {
-	// spew.Dump(c.ApplicationDateFormatShort)
-	// spew.Dump(c)
-	// os.Exit(1)
 	now := time.Now()
-	return fmt.Sprintf("%v", now.Format(config.ApplicationDateFormatShort))
+	return fmt.Sprintf("%v", locl.Current().ShortDate(now))
 }
 
-// The timeString function returns the current time in the format "15:04:05".
+// The timeString function returns the current time in the active locale's form, such as "15:04".
 func TimeString() string {
 	now := time.Now()
-	return fmt.Sprintf("%v", now.Format(config.ApplicationTimeFormat))
+	return fmt.Sprintf("%v", locl.Current().Time(now))
 }
 
 // The dateTimeString function returns a string that combines the time and date strings.
@@ -41,9 +34,9 @@ func DateTimeString() string {
 	return TimeString() + symb.Space.Symbol() + DateString()
 }
 
-// formatDate returns a formatted date string based on the given time.Time value.
+// formatDate returns a formatted date string based on the given time.Time value, in the active locale's long form.
 func FormatDate(t time.Time) string {
-	return t.Format(config.ApplicationDateFormat)
+	return locl.Current().Date(t)
 }
 
 func FormatDuration(t time.Duration) string {
@@ -59,17 +52,9 @@ func TimeAgo(t string) string {
 
 	if t != "" {
 		mdt, _ := time.Parse(time.RFC1123Z, t)
-		rtn := timeago.English.Format(mdt)
-		rtn = strings.Replace(rtn, lang.OneWord.Text(), lang.OneNumeric.Text(), -1)
-		rtn = strings.Replace(rtn, lang.Minutes.Text(), lang.MinutesShort.Text(), -1)
-		rtn = strings.Replace(rtn, lang.Hour.Text(), lang.HourShort.Text(), -1)
+		rtn := locl.Current().RelativeTime(mdt, time.Now())
 		//fix len to 10 chars
-		if len(rtn) > 10 {
-			rtn = rtn[:10]
-		}
-		if len(rtn) < 10 {
-			rtn = strings.Repeat(symb.Space.Symbol(), 10-len(rtn)) + rtn
-		}
+		rtn = wdth.PadLeft(wdth.Truncate(rtn, 10), 10)
 		return rtn
 	}
 	return ""
@@ -0,0 +1,102 @@
+package errors
+
+import (
+	"errors"
+
+	tmpl "github.com/mt1976/crt/language/template"
+)
+
+// Severity is how serious an error is, which decides how it is shown to the user.
+type Severity int
+
+const (
+	Info    Severity = iota // Nothing went wrong, such as input being cancelled
+	Warning                 // The user can correct it and try again
+	Error                   // The operation failed
+	Fatal                   // The application can not continue
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Fatal:
+		return "fatal"
+	}
+	return "error"
+}
+
+// CrtError is an error with a stable code, a severity and a message template whose placeholders,
+// such as {path}, are filled in from Args. It may wrap the error that caused it.
+type CrtError struct {
+	Code     string   // Identifies the error, whatever its message or arguments
+	Severity Severity // How serious the error is
+	Template string   // The message, with placeholders
+	Args     []any    // The values of the placeholders
+	Cause    error    // The error that caused this one, if any
+}
+
+// New returns an error with the given code, severity and message template.
+func New(code string, severity Severity, template string) *CrtError {
+	return &CrtError{Code: code, Severity: severity, Template: template}
+}
+
+// Error returns the message, with its placeholders filled in when there are arguments, followed by
+// the cause if there is one.
+func (e *CrtError) Error() string {
+	msg := e.Template
+	if len(e.Args) > 0 {
+		msg = tmpl.Format(e.Template, e.Args...)
+	}
+	if e.Cause != nil {
+		msg += ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the error that caused this one.
+func (e *CrtError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is a CrtError with the same code, so errors made with With or Wrap
+// still match the error they came from.
+func (e *CrtError) Is(target error) bool {
+	t, ok := target.(*CrtError)
+	return ok && t.Code == e.Code
+}
+
+// With returns a copy of the error with its placeholders filled in from args.
+func (e *CrtError) With(args ...any) *CrtError {
+	c := *e
+	c.Args = args
+	return &c
+}
+
+// Wrap returns a copy of the error caused by cause.
+func (e *CrtError) Wrap(cause error) *CrtError {
+	c := *e
+	c.Cause = cause
+	return &c
+}
+
+// SeverityOf returns the severity of err, or Error if it is not a CrtError.
+func SeverityOf(err error) Severity {
+	var e *CrtError
+	if errors.As(err, &e) {
+		return e.Severity
+	}
+	return Error
+}
+
+// Code returns the code of err, or "" if it is not a CrtError.
+func Code(err error) string {
+	var e *CrtError
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func Test_CrtError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		target   error
+		want     string
+		severity Severity
+		code     string
+	}{
+		{"Plain", ErrNoMorePages, ErrNoMorePages, "no more pages", Warning, "no_more_pages"},
+		{"With args", ErrNotAFile.With("/tmp"), ErrNotAFile, "/tmp is not a file", Error, "not_a_file"},
+		{"Wrapped cause", ErrConfigRead.Wrap(io.EOF), io.EOF, "unable to read configuration: EOF", Error, "config_read"},
+		{"Wrapped by fmt", fmt.Errorf("%w: %v", ErrInputCancelled, "key"), ErrInputCancelled, "input cancelled: key", Info, "input_cancelled"},
+		{"Fatal", ErrTerminalSize.With(80, 24), ErrTerminalSize, "invalid terminal size 80 24", Fatal, "terminal_size"},
+		{"Not a CrtError", io.EOF, io.EOF, "EOF", Error, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+			if !errors.Is(tt.err, tt.target) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", tt.err, tt.target)
+			}
+			if got := SeverityOf(tt.err); got != tt.severity {
+				t.Errorf("SeverityOf() = %v, want %v", got, tt.severity)
+			}
+			if got := Code(tt.err); got != tt.code {
+				t.Errorf("Code() = %q, want %q", got, tt.code)
+			}
+		})
+	}
+}
+
+func Test_CrtErrorAs(t *testing.T) {
+	err := fmt.Errorf("loading: %w", ErrInvalidPath.With("/nowhere").Wrap(io.ErrUnexpectedEOF))
+	var e *CrtError
+	if !errors.As(err, &e) {
+		t.Fatalf("errors.As(%v) = false, want true", err)
+	}
+	if e.Code != "invalid_path" || e.Cause != io.ErrUnexpectedEOF {
+		t.Errorf("errors.As() = %+v, want invalid_path caused by %v", e, io.ErrUnexpectedEOF)
+	}
+	if errors.Is(err, ErrNotAFile) {
+		t.Errorf("errors.Is(%v, ErrNotAFile) = true, want false", err)
+	}
+	if ErrInvalidPath.Args != nil || ErrInvalidPath.Cause != nil {
+		t.Errorf("With and Wrap changed the original error")
+	}
+}
+
+func Test_Format(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		args []any
+		want string
+	}{
+		{"Placeholders filled", ErrNotAFile, []any{"/tmp"}, "/tmp is not a file"},
+		{"Already filled", ErrNotAFile.With("{x}"), []any{"more"}, "{x} is not a file more"},
+		{"Braces in a cause", ErrConfigRead.Wrap(errors.New("bad {{ value }")), nil, "unable to read configuration: bad {{ value }"},
+		{"Not a CrtError", errors.New("no {path} here"), []any{"/tmp"}, "no {path} here /tmp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Format(tt.err, tt.args...); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
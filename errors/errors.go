@@ -1,35 +1,75 @@
 package errors
 
-import "errors"
+import (
+	"fmt"
+)
 
 var (
-	ErrTerminalSize                = errors.New("invalid terminal size %v %v")
-	ErrBaudRateError               = errors.New("invalid baud rate %v")
-	ErrDurationMismatch            = errors.New("duration mismatch")
-	ErrMaxPageRows                 = errors.New("max page rows reached")
-	ErrNoActionSpecified           = errors.New("no action specified")
-	ErrInvalidAction               = errors.New("invalid action specified. [%v]")
-	ErrInvalidActionLen            = errors.New("invalid action length. [%v] %v Characters should be %v")
-	ErrInputFailure                = errors.New("unable to get input data") // ErrInputFailure is returned when the input fails
-	ErrInputScannerFailure         = errors.New("unable to get input data") // ErrInputScannerFailure is returned when the input scanner fails
-	ErrNoMorePages                 = errors.New("no more pages")
-	ErrAddColumns                  = errors.New("too many columns have %v should be %v or less")
-	ErrConfigurationColumnMismatch = errors.New("column mismatch in configuration got %v wanted %v in %s")
-	ErrDashboardNoHost             = errors.New("dashboard: No default host set")
-	ErrHostName                    = errors.New("unable to get hostname")
-	ErrUserName                    = errors.New("unable to get username")
-	ErrSystemInfo                  = errors.New("unable to get machine name")
-	ErrInputLengthMinimum          = errors.New("text must be at least %v characters")
-	ErrInputLengthMaximum          = errors.New("text must be at most %v characters, is %v")
-	ErrNoPromptSpecified           = errors.New("no prompt specified")
-	ErrNoEmptyDirectories          = errors.New("unable to find empty directories %v")
-	ErrUnableToRemoveDirectories   = errors.New("unable to remove empty directories %v")
-	ErrUnableToFindFiles           = errors.New("unable to find files %v")
-	ErrUnableToResolvePath         = errors.New("unable to resolve path %v")
-	ErrNoPathSpecified             = errors.New("no path specification specified %v")
-	ErrInvalidPath                 = errors.New("the path provided is not valid %v")
-	ErrInvalidPathSpecialDirectory = errors.New("the path provided is the root or home directory")
-	ErrFailedToChangeDirectory     = errors.New("failed to change directory to %v %v")
-	ErrNotADirectory               = errors.New("%v is not a directory")
-	ErrNotAFile                    = errors.New("%v is not a file")
+	ErrTerminalSize                = New("terminal_size", Fatal, "invalid terminal size {width} {height}")
+	ErrBaudRateError               = New("baud_rate_error", Error, "invalid baud rate {baud}")
+	ErrDurationMismatch            = New("duration_mismatch", Error, "duration mismatch")
+	ErrMaxPageRows                 = New("max_page_rows", Warning, "max page rows reached")
+	ErrNoActionSpecified           = New("no_action_specified", Warning, "no action specified")
+	ErrInvalidAction               = New("invalid_action", Warning, "invalid action specified. [{action}]")
+	ErrInvalidActionLen            = New("invalid_action_len", Warning, "invalid action length. [{action}] {length, plural, one {# Character} other {# Characters}} should be {max}")
+	ErrInputFailure                = New("input_failure", Error, "unable to get input data")         // ErrInputFailure is returned when the input fails
+	ErrInputScannerFailure         = New("input_scanner_failure", Error, "unable to get input data") // ErrInputScannerFailure is returned when the input scanner fails
+	ErrInputInterrupted            = New("input_interrupted", Info, "input interrupted")
+	ErrInputCancelled              = New("input_cancelled", Info, "input cancelled")
+	ErrSecretMismatch              = New("secret_mismatch", Warning, "the entries do not match, please try again")
+	ErrUnknownTheme                = New("unknown_theme", Error, "unknown theme {theme}")
+	ErrInvalidTheme                = New("invalid_theme", Error, "invalid theme {path}")
+	ErrUnknownBoxStyle             = New("unknown_box_style", Error, "unknown box style {style}")
+	ErrInvalidSpinner              = New("invalid_spinner", Error, "invalid spinner {name}")
+	ErrInvalidCatalog              = New("invalid_catalog", Error, "invalid message catalog {path}")
+	ErrConfigNotFound              = New("config_not_found", Error, "configuration file not found: {file}")
+	ErrConfigRead                  = New("config_read", Error, "unable to read configuration")
+	ErrInvalidConfig               = New("invalid_config", Error, "invalid configuration: {setting} {problem}")
+	ErrConfigWatch                 = New("config_watch", Error, "unable to watch configuration")
+	ErrDuplicateSetting            = New("duplicate_setting", Error, "flag --{flag} is used by both {first} and {second}")
+	ErrNoMorePages                 = New("no_more_pages", Warning, "no more pages")
+	ErrNoSuchPanel                 = New("no_such_panel", Warning, "there is no panel {panel}, panels are numbered 1 to {panels}")
+	ErrAddColumns                  = New("add_columns", Error, "too many columns have {columns} should be {max} or less")
+	ErrConfigurationColumnMismatch = New("configuration_column_mismatch", Error, "column mismatch in configuration got {got} wanted {wanted} in {setting}")
+	ErrDashboardNoHost             = New("dashboard_no_host", Error, "dashboard: No default host set")
+	ErrHostName                    = New("host_name", Error, "unable to get hostname")
+	ErrUserName                    = New("user_name", Error, "unable to get username")
+	ErrSystemInfo                  = New("system_info", Error, "unable to get machine name")
+	ErrInputLengthMinimum          = New("input_length_minimum", Warning, "text must be at least {min, plural, one {# character} other {# characters}}")
+	ErrInputLengthMaximum          = New("input_length_maximum", Warning, "text must be at most {max, plural, one {# character} other {# characters}}, is {length}")
+	ErrNoPromptSpecified           = New("no_prompt_specified", Fatal, "no prompt specified, {hint}")
+	ErrNoEmptyDirectories          = New("no_empty_directories", Error, "unable to find empty directories {path}")
+	ErrUnableToRemoveDirectories   = New("unable_to_remove_directories", Error, "unable to remove empty directories {path}")
+	ErrUnableToFindFiles           = New("unable_to_find_files", Error, "unable to find files {path}")
+	ErrUnableToResolvePath         = New("unable_to_resolve_path", Error, "unable to resolve path {path}")
+	ErrNoPathSpecified             = New("no_path_specified", Error, "no path specification specified {path}")
+	ErrInvalidPath                 = New("invalid_path", Error, "the path provided is not valid {path}")
+	ErrInvalidPathSpecialDirectory = New("invalid_path_special_directory", Error, "the path provided is the root or home directory")
+	ErrFailedToChangeDirectory     = New("failed_to_change_directory", Error, "failed to change directory to {path} {reason}")
+	ErrNotADirectory               = New("not_a_directory", Error, "{path} is not a directory")
+	ErrNotAFile                    = New("not_a_file", Error, "{path} is not a file")
+	ErrMissingArgument             = New("missing_argument", Warning, "missing argument, usage: {usage}")
+	ErrTooManyArguments            = New("too_many_arguments", Warning, "too many arguments, usage: {usage}")
+	ErrInvalidArgument             = New("invalid_argument", Warning, "invalid argument, usage: {usage}")
+	ErrInvalidKeyBinding           = New("invalid_key_binding", Error, "invalid key binding {binding}, should be an action and a key, such as Quit:X")
+	ErrDuplicateKeyBinding         = New("duplicate_key_binding", Error, "key {key} is bound to both {first} and {second}")
+	ErrUnknownLocale               = New("unknown_locale", Error, "unknown locale {locale}")
+	ErrInvalidRandomRange          = New("invalid_random_range", Error, "{name}Min and {name}Max must be between {lowest} and {limit}, with Min no more than Max, are {min} and {max}")
+	ErrInvalidFieldName            = New("invalid_field_name", Error, "invalid object type [{type}]")
 )
+
+// Format returns the error's message with its placeholders, such as {path}, filled in from args.
+// Arguments are bound to the placeholders in the order they first appear in the message, and any
+// left over are added to the end. Only a CrtError that has not been filled in by With has its
+// placeholders filled; the message of any other error is not read as a template, and args are added
+// to the end of it.
+func Format(err error, args ...any) string {
+	if e, ok := err.(*CrtError); ok && len(e.Args) == 0 && len(args) > 0 {
+		return e.With(args...).Error()
+	}
+	msg := err.Error()
+	for _, arg := range args {
+		msg = msg + " " + fmt.Sprint(arg)
+	}
+	return msg
+}
@@ -0,0 +1,74 @@
+package filechooser
+
+import (
+	"path/filepath"
+	"strings"
+
+	inpt "github.com/mt1976/crt/input"
+)
+
+// PathCompleter completes file system paths typed at the prompt, using the same flags as the file
+// chooser to decide which items are offered.
+type PathCompleter struct {
+	base  string
+	flags flagger
+}
+
+// NewPathCompleter returns a completer for paths relative to the base directory. Absolute paths, and
+// paths starting with ~, are completed as typed.
+func NewPathCompleter(base string, flags flagger) *PathCompleter {
+	return &PathCompleter{base: base, flags: flags}
+}
+
+// Complete returns the files and directories that complete the partial path.
+func (c *PathCompleter) Complete(word string) []inpt.Candidate {
+	dir, prefix := splitPath(word)
+
+	search, err := resolvePath(c.base, dir)
+	if err != nil {
+		return nil
+	}
+	files, err := GetFolderContent(search, c.flags)
+	if err != nil {
+		return nil
+	}
+
+	var matches []inpt.Candidate
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name, prefix) {
+			continue
+		}
+		name := file.Name
+		if file.IsDir {
+			name = name + pathSeparator
+		}
+		matches = append(matches, inpt.Candidate{Value: dir + name, Display: name})
+	}
+	return matches
+}
+
+// resolvePath returns the path as typed, relative to the base directory unless it is absolute or
+// starts with ~, the user's home directory.
+func resolvePath(base, path string) (string, error) {
+	switch {
+	case strings.HasPrefix(path, "~"):
+		home, err := UserHome()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+	case !filepath.IsAbs(path):
+		return filepath.Join(base, path), nil
+	}
+	return filepath.Clean(path), nil
+}
+
+// splitPath splits a partial path into the directory, including its trailing separator, and the
+// partial name being typed.
+func splitPath(word string) (string, string) {
+	i := strings.LastIndex(word, pathSeparator)
+	if i < 0 {
+		return "", word
+	}
+	return word[:i+1], word[i+1:]
+}
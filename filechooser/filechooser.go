@@ -4,14 +4,15 @@ import (
 	"fmt"
 	"os"
 	"os/user"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	errs "github.com/mt1976/crt/errors"
 	lang "github.com/mt1976/crt/language"
-	numb "github.com/mt1976/crt/numbers"
 	page "github.com/mt1976/crt/page"
 	actn "github.com/mt1976/crt/page/actions"
-	strg "github.com/mt1976/crt/strings"
 	symb "github.com/mt1976/crt/strings/symbols"
 	sppt "github.com/mt1976/crt/support"
 	term "github.com/mt1976/crt/terminal"
@@ -28,6 +29,14 @@ type File struct {
 	Icon     string
 	Mode     string
 	Seq      int
+	ModTime  time.Time
+}
+
+// view holds the sort order and filter applied to the file list between redraws.
+type view struct {
+	sortBy  string
+	order   string
+	pattern string
 }
 
 type flagger struct {
@@ -63,6 +72,10 @@ var pathSeparator = string(os.PathSeparator)
 //
 //	(string, bool, error): The chosen file or directory, a boolean indicating whether it is a directory, and an error if one occurred.
 func FileChooser(searchPath string, flags flagger) (string, bool, error) {
+	return fileChooser(searchPath, flags, view{})
+}
+
+func fileChooser(searchPath string, flags flagger, v view) (string, bool, error) {
 
 	if searchPath == "" {
 		return "", false, errs.ErrInvalidPathSpecialDirectory
@@ -85,6 +98,7 @@ func FileChooser(searchPath string, flags flagger) (string, bool, error) {
 	if err != nil {
 		return "", false, err
 	}
+	files = v.apply(files)
 
 	// Add information about the current user and directory to the page
 	uh, _ := UserHome()
@@ -121,17 +135,28 @@ func FileChooser(searchPath string, flags flagger) (string, bool, error) {
 	p.AddAction(actn.UpDoubleDot)
 	p.AddAction(actn.Select)
 
+	// Add commands to drill down into a directory, change to a typed path, sort and filter the list
+	goTo := actn.New(actn.Go.Action()).WithArgs(actn.NewArg("item", actn.IntArg))
+	changeDir := actn.New(actn.ChangeDir.Action()).WithArgs(
+		actn.NewArg("path", actn.StringArg).CompletedBy(NewPathCompleter(searchPath, DirectoriesAll)),
+	)
+	sortBy := actn.New(actn.Sort.Action()).WithArgs(
+		actn.NewArg("field", actn.ChoiceArg).Choices("name", "size", "modified"),
+		actn.NewArg("order", actn.ChoiceArg).Choices("asc", "desc").Optional("asc"),
+	)
+	find := actn.New(actn.Find.Action()).WithArgs(
+		actn.NewArg("pattern", actn.PatternArg).Optional(""),
+	)
+	p.AddAction(goTo)
+	p.AddAction(changeDir)
+	p.AddAction(sortBy)
+	p.AddAction(find)
+
 	// Add options for each file or directory in the list
 	for _, file := range files {
 		// Create a row for the file or directory
 		row := fmt.Sprintf("%-1s %-30s | %-10s | %-12s | %-15s", file.Icon, file.Name, file.Mode, file.Modified, file.SizeTxt)
 		p.AddMenuOption(file.Seq+1, row, "", "")
-
-		// Add an action for selecting the directory if it is a directory
-		if file.IsDir {
-			act := actn.New(actn.Go.Action() + fmt.Sprintf("%v", file.Seq+1))
-			p.AddAction(act)
-		}
 	}
 
 	// Display the file chooser with actions
@@ -156,39 +181,110 @@ func FileChooser(searchPath string, flags flagger) (string, bool, error) {
 		p.Dump(fmt.Sprintf("af upPath: %v\n", upPath))
 		toPath := strings.Join(upPath, pathSeparator)
 		p.Dump("Relaunch FileChooser", toPath, actn.Up.Action(), actn.UpArrow.Action(), actn.UpDoubleDot.Action())
-		return FileChooser(toPath, flags)
+		return fileChooser(toPath, flags, v)
 	}
 
-	// Split the action into its first character and the remaining characters
-	first := upcase(nextAction.Action()[:1])
-	remainder := nextAction.Action()[1:]
+	// Redraw the list with the new sort order or filter
+	if nextAction.Is(sortBy) {
+		return fileChooser(searchPath, flags, v.sorted(nextAction.Args()))
+	}
+	if nextAction.Is(find) {
+		return fileChooser(searchPath, flags, v.filtered(nextAction.Args()))
+	}
 
-	// Handle actions for selecting a directory or file
-	if actn.Go.Equals(first) && isInt(remainder) {
-		r := files[t.Helpers.ToInt(remainder)-1]
+	// Handle a change to a typed directory
+	if nextAction.Is(changeDir) {
+		toPath, err := resolvePath(searchPath, nextAction.Args().String("path"))
+		if err != nil {
+			return "", false, err
+		}
+		if info, err := os.Stat(toPath); err != nil || !info.IsDir() {
+			p.Error(errs.ErrNotADirectory.With(toPath))
+			return fileChooser(searchPath, flags, v)
+		}
+		return fileChooser(toPath, flags, view{})
+	}
+
+	// Handle actions for selecting a directory
+	if nextAction.Is(goTo) {
+		item := nextAction.Args().Int("item")
+		if item < 1 || item > len(files) {
+			p.Error(errs.ErrInvalidAction.With(nextAction.Args().String("item")))
+			return fileChooser(searchPath, flags, v)
+		}
+		r := files[item-1]
 		if !r.IsDir {
-			p.Error(errs.ErrNotADirectory, r.Path)
-			return FileChooser(searchPath, flags)
+			p.Error(errs.ErrNotADirectory.With(r.Path))
+			return fileChooser(searchPath, flags, v)
 		}
-		p.Dump("Drilldown", r.Path, first, actn.Go.Action())
-		return FileChooser(r.Path, flags)
+		p.Dump("Drilldown", r.Path, actn.Go.Action())
+		return fileChooser(r.Path, flags, view{})
 	}
 
 	// Handle selection of a specific file or directory
 	if nextAction.IsInt() {
 		r := files[t.Helpers.ToInt(nextAction.Action())-1]
 		if !r.IsDir && flags.allowDirs {
-			p.Error(errs.ErrNotAFile, r.Path)
-			return FileChooser(searchPath, flags)
+			p.Error(errs.ErrNotAFile.With(r.Path))
+			return fileChooser(searchPath, flags, v)
 		}
 		if r.IsDir && flags.allowFiles {
-			p.Error(errs.ErrNotADirectory, r.Path)
-			return FileChooser(searchPath, flags)
+			p.Error(errs.ErrNotADirectory.With(r.Path))
+			return fileChooser(searchPath, flags, v)
 		}
 		return r.Path, r.IsDir, nil
 	}
 
-	return FileChooser(searchPath, flags)
+	return fileChooser(searchPath, flags, v)
+}
+
+// sorted returns the view sorted by the field and order given to SORT.
+func (v view) sorted(args *actn.Args) view {
+	v.sortBy = args.String("field")
+	v.order = args.String("order")
+	return v
+}
+
+// filtered returns the view showing only the names matching the pattern given to FIND, or every
+// name if none was given.
+func (v view) filtered(args *actn.Args) view {
+	v.pattern = args.String("pattern")
+	return v
+}
+
+// apply filters and sorts the list of files according to the view, renumbering the items to match.
+func (v view) apply(files []File) []File {
+	var out []File
+	for _, file := range files {
+		if v.pattern != "" {
+			if ok, _ := filepath.Match(v.pattern, file.Name); !ok {
+				continue
+			}
+		}
+		out = append(out, file)
+	}
+
+	if v.sortBy != "" {
+		sort.SliceStable(out, func(i, j int) bool {
+			a, b := out[i], out[j]
+			if v.order == "desc" {
+				a, b = b, a
+			}
+			switch v.sortBy {
+			case "size":
+				return a.Size < b.Size
+			case "modified":
+				return a.ModTime.Before(b.ModTime)
+			default:
+				return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+			}
+		})
+	}
+
+	for i := range out {
+		out[i].Seq = i
+	}
+	return out
 }
 
 func brk(page *page.Page, breakChar string) {
@@ -242,6 +338,7 @@ func GetFolderContent(dir string, include flagger) ([]File, error) {
 		this.Path = dir + pathSeparator + file.Name()
 		inf, _ := file.Info()
 		this.Created = lang.FileChooserNotAvailable.Text()
+		this.ModTime = inf.ModTime()
 		this.Modified = term.New().Formatters.HumanFromUnixDate(inf.ModTime().Local().Unix())
 		this.Size = inf.Size()
 		yy := fmt.Sprintf("%v", this.Size)
@@ -290,16 +387,6 @@ func ChooseDirectory(root string) (string, error) {
 	return item, err
 }
 
-// isInt returns true if the input string can be converted to an integer.
-func isInt(s string) bool {
-	return numb.IsInt(s)
-}
-
-// upcase returns the input string with all characters converted to uppercase.
-func upcase(s string) string {
-	return strg.Upcase(s)
-}
-
 // UserHome returns the home directory of the current user, or an error if it cannot be determined.
 func UserHome() (string, error) {
 	// Function gets the home directory of the current user, or returns an error if it cant.
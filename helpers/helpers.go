@@ -6,6 +6,7 @@ import (
 	gtrm "github.com/buger/goterm"
 	colr "github.com/fatih/color"
 	dttm "github.com/mt1976/crt/datesTimes"
+	locl "github.com/mt1976/crt/language/locale"
 	mock "github.com/mt1976/crt/mock"
 	numb "github.com/mt1976/crt/numbers"
 	actn "github.com/mt1976/crt/page/actions"
@@ -48,6 +49,11 @@ type Formatters struct {
 	TimeAgo                 func(t string) string
 	FormatDate              func(t time.Time) string
 	FormatDuration          func(t time.Duration) string
+	Number                  func(v any) string
+	Decimal                 func(v float64, places int) string
+	Currency                func(amount float64) string
+	ShortDate               func(t time.Time) string
+	RelativeTime            func(t time.Time) string
 }
 
 type Styles struct {
@@ -115,6 +121,11 @@ func InitFormatters() *Formatters {
 		TimeAgo:                 dttm.TimeAgo,
 		FormatDate:              dttm.FormatDate,
 		FormatDuration:          dttm.FormatDuration,
+		Number:                  func(v any) string { return locl.Current().Number(v) },
+		Decimal:                 func(v float64, places int) string { return locl.Current().Decimal(v, places) },
+		Currency:                func(amount float64) string { return locl.Current().Currency(amount) },
+		ShortDate:               func(t time.Time) string { return locl.Current().ShortDate(t) },
+		RelativeTime:            func(t time.Time) string { return locl.Current().RelativeTime(t, time.Now()) },
 	}
 	return &fmts
 }
@@ -150,27 +161,27 @@ func InitStyles() *Styles {
 }
 
 func red(s string) string {
-	return gtrm.Color(s, gtrm.RED)
+	return colour(s, gtrm.RED)
 }
 
 func green(s string) string {
-	return gtrm.Color(s, gtrm.GREEN)
+	return colour(s, gtrm.GREEN)
 }
 
 func yellow(s string) string {
-	return gtrm.Color(s, gtrm.YELLOW)
+	return colour(s, gtrm.YELLOW)
 }
 
 func blue(s string) string {
-	return gtrm.Color(s, gtrm.BLUE)
+	return colour(s, gtrm.BLUE)
 }
 
 func magenta(s string) string {
-	return gtrm.Color(s, gtrm.MAGENTA)
+	return colour(s, gtrm.MAGENTA)
 }
 
 func cyan(s string) string {
-	return gtrm.Color(s, gtrm.CYAN)
+	return colour(s, gtrm.CYAN)
 }
 
 func grey(s string) string {
@@ -183,13 +194,24 @@ func gray(s string) string {
 }
 
 func white(s string) string {
-	return gtrm.Color(s, gtrm.WHITE)
+	return colour(s, gtrm.WHITE)
 }
 
 func bold(s string) string {
+	if !styl.Enabled() {
+		return s
+	}
 	return gtrm.Bold(s)
 }
 
+// colour returns the string in the given colour, or unchanged if the terminal does not display colour.
+func colour(s string, c int) string {
+	if !styl.Enabled() {
+		return s
+	}
+	return gtrm.Color(s, c)
+}
+
 func underline(s string) string {
 	und := colr.New(colr.Underline)
 	return und.Sprint(s)
@@ -0,0 +1,136 @@
+package input
+
+import (
+	"strings"
+	"unicode"
+)
+
+// buffer holds the text being edited and the position of the cursor within it.
+type buffer struct {
+	text   []rune
+	cursor int
+}
+
+// String returns the text held in the buffer.
+func (b *buffer) String() string {
+	return string(b.text)
+}
+
+// set replaces the text in the buffer, placing the cursor at the end.
+func (b *buffer) set(s string) {
+	b.text = []rune(s)
+	b.cursor = len(b.text)
+}
+
+// wipe overwrites the contents of the buffer before emptying it.
+func (b *buffer) wipe() {
+	b.text = b.text[:cap(b.text)]
+	for i := range b.text {
+		b.text[i] = 0
+	}
+	b.text = nil
+	b.cursor = 0
+}
+
+func (b *buffer) insert(s string) {
+	r := []rune(s)
+	b.text = append(b.text[:b.cursor], append(r, b.text[b.cursor:]...)...)
+	b.cursor += len(r)
+}
+
+func (b *buffer) backspace() {
+	if b.cursor == 0 {
+		return
+	}
+	b.text = append(b.text[:b.cursor-1], b.text[b.cursor:]...)
+	b.cursor--
+}
+
+func (b *buffer) delete() {
+	if b.cursor >= len(b.text) {
+		return
+	}
+	b.text = append(b.text[:b.cursor], b.text[b.cursor+1:]...)
+}
+
+func (b *buffer) left() {
+	if b.cursor > 0 {
+		b.cursor--
+	}
+}
+
+func (b *buffer) right() {
+	if b.cursor < len(b.text) {
+		b.cursor++
+	}
+}
+
+func (b *buffer) home() {
+	b.cursor = 0
+}
+
+func (b *buffer) end() {
+	b.cursor = len(b.text)
+}
+
+// wordStart returns the position of the start of the word before the cursor.
+func (b *buffer) wordStart() int {
+	i := b.cursor
+	for i > 0 && unicode.IsSpace(b.text[i-1]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(b.text[i-1]) {
+		i--
+	}
+	return i
+}
+
+func (b *buffer) wordLeft() {
+	b.cursor = b.wordStart()
+}
+
+func (b *buffer) wordRight() {
+	i := b.cursor
+	for i < len(b.text) && unicode.IsSpace(b.text[i]) {
+		i++
+	}
+	for i < len(b.text) && !unicode.IsSpace(b.text[i]) {
+		i++
+	}
+	b.cursor = i
+}
+
+// deleteWord removes the word before the cursor.
+func (b *buffer) deleteWord() {
+	start := b.wordStart()
+	b.text = append(b.text[:start], b.text[b.cursor:]...)
+	b.cursor = start
+}
+
+func (b *buffer) killLine() {
+	b.text = b.text[:0]
+	b.cursor = 0
+}
+
+func (b *buffer) killToEnd() {
+	b.text = b.text[:b.cursor]
+}
+
+// currentWord returns the partial word immediately before the cursor, and the words on the line
+// before it, none if it is the first word.
+func (b *buffer) currentWord() (string, []string) {
+	i := b.cursor
+	for i > 0 && !unicode.IsSpace(b.text[i-1]) {
+		i--
+	}
+	return string(b.text[i:b.cursor]), strings.Fields(string(b.text[:i]))
+}
+
+// replaceWord replaces the partial word before the cursor with s.
+func (b *buffer) replaceWord(s string) {
+	word, _ := b.currentWord()
+	start := b.cursor - len([]rune(word))
+	b.text = append(b.text[:start], b.text[b.cursor:]...)
+	b.cursor = start
+	b.insert(s)
+}
@@ -0,0 +1,121 @@
+package input
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_buffer(t *testing.T) {
+	tests := []struct {
+		name       string
+		start      string
+		edit       func(b *buffer)
+		want       string
+		wantCursor int
+	}{
+		{"Insert at end", "abc", func(b *buffer) { b.insert("d") }, "abcd", 4},
+		{"Insert after home", "abc", func(b *buffer) { b.home(); b.insert("x") }, "xabc", 1},
+		{"Backspace in middle", "abc", func(b *buffer) { b.left(); b.backspace() }, "ac", 1},
+		{"Backspace at start", "abc", func(b *buffer) { b.home(); b.backspace() }, "abc", 0},
+		{"Delete under cursor", "abc", func(b *buffer) { b.home(); b.delete() }, "bc", 0},
+		{"Right stops at end", "ab", func(b *buffer) { b.right(); b.right() }, "ab", 2},
+		{"Delete word", "sort name desc", func(b *buffer) { b.deleteWord() }, "sort name ", 10},
+		{"Delete word with trailing space", "sort name ", func(b *buffer) { b.deleteWord() }, "sort ", 5},
+		{"Word left and right", "one two three", func(b *buffer) { b.wordLeft(); b.wordLeft(); b.wordRight() }, "one two three", 7},
+		{"Kill to end", "one two", func(b *buffer) { b.wordLeft(); b.killToEnd() }, "one ", 4},
+		{"Multibyte runes", "café", func(b *buffer) { b.backspace(); b.insert("é!") }, "café!", 5},
+		{"Replace word", "G fo", func(b *buffer) { b.replaceWord("folder ") }, "G folder ", 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &buffer{}
+			b.set(tt.start)
+			tt.edit(b)
+			if got := b.String(); got != tt.want {
+				t.Errorf("buffer = %q, want %q", got, tt.want)
+			}
+			if b.cursor != tt.wantCursor {
+				t.Errorf("cursor = %v, want %v", b.cursor, tt.wantCursor)
+			}
+		})
+	}
+}
+
+func Test_history(t *testing.T) {
+	h := &History{}
+	e := &LineEditor{History: h}
+	h.Add("first")
+	h.Add("second")
+	h.Add("second")
+	h.Add("  ")
+	if h.Len() != 2 {
+		t.Fatalf("History.Len() = %v, want 2", h.Len())
+	}
+
+	e.histPos = h.Len()
+	e.buf.set("draft")
+	e.historyBack()
+	e.historyBack()
+	e.historyBack()
+	if got := e.buf.String(); got != "first" {
+		t.Errorf("after back = %q, want %q", got, "first")
+	}
+	e.historyForward()
+	e.historyForward()
+	if got := e.buf.String(); got != "draft" {
+		t.Errorf("after forward = %q, want %q", got, "draft")
+	}
+}
+
+func Test_secret(t *testing.T) {
+	tests := []struct {
+		name string
+		mask string
+		want string
+	}{
+		{"Masked", "*", "\033[5;3H****  \033[5;7H"},
+		{"Hidden", "", "\033[5;3H      \033[5;3H"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &strings.Builder{}
+			e := NewSecretEditor(3, 5, 6, tt.mask)
+			e.out = out
+			e.Args = Words("pa55word")
+			e.buf.set("pa55")
+			e.complete()
+			e.render()
+			if got := out.String(); got != tt.want {
+				t.Errorf("render() = %q, want %q", got, tt.want)
+			}
+			e.buf.wipe()
+			if e.buf.String() != "" {
+				t.Errorf("wipe() left %q", e.buf.String())
+			}
+		})
+	}
+}
+
+func Test_renderWaitsForScreen(t *testing.T) {
+	out := &strings.Builder{}
+	e := NewLineEditor(3, 5, 6)
+	e.out = out
+
+	Screen.Lock()
+	done := make(chan struct{})
+	go func() {
+		e.render()
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("render() drew while the screen was held")
+	case <-time.After(20 * time.Millisecond):
+	}
+	Screen.Unlock()
+	<-done
+	if out.Len() == 0 {
+		t.Error("render() drew nothing once the screen was free")
+	}
+}
@@ -0,0 +1,82 @@
+package input
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Candidate is a possible completion for a partial word. Value replaces the word on the input line,
+// Display is shown when several candidates match.
+type Candidate struct {
+	Value   string
+	Display string
+}
+
+// Completer supplies the candidates that complete a partial word.
+type Completer interface {
+	Complete(word string) []Candidate
+}
+
+// CompleterFunc allows an ordinary function to be used as a Completer.
+type CompleterFunc func(word string) []Candidate
+
+// Complete calls f(word).
+func (f CompleterFunc) Complete(word string) []Candidate {
+	return f(word)
+}
+
+// ArgCompleterFunc returns the Completer for the argument at index, counting from 0, typed after
+// the command, or nil if the argument has none of its own.
+type ArgCompleterFunc func(command string, index int) Completer
+
+// Combine returns a Completer that offers the candidates of every given completer, without
+// duplicates.
+func Combine(completers ...Completer) Completer {
+	return CompleterFunc(func(word string) []Candidate {
+		var out []Candidate
+		seen := map[string]bool{}
+		for _, c := range completers {
+			if c == nil {
+				continue
+			}
+			for _, candidate := range c.Complete(word) {
+				if seen[candidate.Value] {
+					continue
+				}
+				seen[candidate.Value] = true
+				out = append(out, candidate)
+			}
+		}
+		return out
+	})
+}
+
+// Words returns a Completer for a fixed list of words, such as host names. Matching is
+// case-insensitive.
+func Words(words ...string) Completer {
+	sorted := append([]string{}, words...)
+	sort.Strings(sorted)
+	return CompleterFunc(func(word string) []Candidate {
+		var out []Candidate
+		for _, w := range sorted {
+			if HasPrefixFold(w, word) {
+				out = append(out, Candidate{Value: w, Display: w})
+			}
+		}
+		return out
+	})
+}
+
+// HasPrefixFold reports whether s begins with prefix, ignoring case. It compares rune by rune, as
+// a letter and its other case can be encoded in a different number of bytes.
+func HasPrefixFold(s, prefix string) bool {
+	for _, p := range prefix {
+		r, size := utf8.DecodeRuneInString(s)
+		if size == 0 || !strings.EqualFold(string(r), string(p)) {
+			return false
+		}
+		s = s[size:]
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_complete(t *testing.T) {
+	commands := Words("SORT", "SELECT", "FIND")
+	hosts := Words("alpha.example.com", "beta.example.com", "bravo.example.com")
+
+	tests := []struct {
+		name     string
+		line     string
+		want     string
+		wantList []string
+	}{
+		{"Unique command", "F", "FIND ", nil},
+		{"Case insensitive", "fi", "FIND ", nil},
+		{"Ambiguous command extends and lists", "S", "S", []string{"SELECT", "SORT"}},
+		{"Ambiguous argument extends to common prefix", "SORT b", "SORT b", []string{"beta.example.com", "bravo.example.com"}},
+		{"Unique argument", "SORT al", "SORT alpha.example.com ", nil},
+		{"No match", "SORT x", "SORT x", nil},
+		{"Argument of its own", "FIND n", "FIND name ", nil},
+		{"Second argument falls back", "FIND name al", "FIND name alpha.example.com ", nil},
+	}
+	argsFor := func(command string, index int) Completer {
+		if command == "FIND" && index == 0 {
+			return Words("name", "size")
+		}
+		return nil
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var listed []string
+			e := &LineEditor{Commands: commands, Args: Combine(hosts, nil), ArgsFor: argsFor}
+			e.List = func(c []Candidate) {
+				for _, x := range c {
+					listed = append(listed, x.Display)
+				}
+			}
+			e.buf.set(tt.line)
+			e.complete()
+			if got := e.buf.String(); got != tt.want {
+				t.Errorf("complete() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(listed, tt.wantList) {
+				t.Errorf("complete() listed %v, want %v", listed, tt.wantList)
+			}
+		})
+	}
+}
+
+func Test_HasPrefixFold(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		prefix string
+		want   bool
+	}{
+		{"Same case", "SORT", "SO", true},
+		{"Other case", "sort", "SO", true},
+		{"Empty prefix", "SORT", "", true},
+		{"Longer prefix", "SO", "SORT", false},
+		{"Different", "SORT", "SE", false},
+		{"Accented", "ÉTAT", "ét", true},
+		{"Kelvin sign is two bytes longer than k", "Kelvin", "ke", true},
+		{"Split rune", "été", "\xc3", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasPrefixFold(tt.s, tt.prefix); got != tt.want {
+				t.Errorf("HasPrefixFold(%q, %q) = %v, want %v", tt.s, tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
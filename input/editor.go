@@ -0,0 +1,276 @@
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	errs "github.com/mt1976/crt/errors"
+	wdth "github.com/mt1976/crt/strings/width"
+	"golang.org/x/term"
+)
+
+// stdin is shared by every reader so that input buffered by one read is not lost to the next.
+var stdin = bufio.NewReader(os.Stdin)
+
+// Screen is held while the editors draw, and should be held by anything else drawing on the screen
+// from another goroutine, such as a timer, so that its cursor movements are not mixed with the input.
+var Screen sync.Mutex
+
+// LineEditor reads a single line of input at a fixed position on the screen, supporting cursor
+// movement, word deletion, history and tab completion.
+type LineEditor struct {
+	column   int                          // The screen column the input starts at
+	row      int                          // The screen row the input is on
+	width    int                          // The number of columns available for input
+	History  *History                     // The history navigated with up/down, may be nil
+	Commands Completer                    // Completes the first word on the line, may be nil
+	Args     Completer                    // Completes the words after the first, may be nil
+	ArgsFor  ArgCompleterFunc             // Completes an action's arguments, in place of Args, may be nil
+	List     func(candidates []Candidate) // Shows the candidates when a completion is ambiguous, may be nil
+	Events   <-chan func()                // Functions run while waiting for a key, such as a redraw, may be nil
+	secret   bool                         // True if the input is a secret, which is not echoed or recorded
+	mask     string                       // Shown in place of each character of a secret, nothing is shown if empty
+	out      io.Writer                    // Where the line is drawn
+	buf      buffer                       // The line being edited
+	offset   int                          // The first rune shown, when the line is wider than the input
+	histPos  int                          // The history entry being shown
+	pending  string                       // The line being edited before history was navigated
+}
+
+// NewLineEditor returns a LineEditor that reads input at the given column and row, using at most
+// width columns.
+func NewLineEditor(column, row, width int) *LineEditor {
+	return &LineEditor{column: column, row: row, width: width, out: os.Stdout}
+}
+
+// NewSecretEditor returns a LineEditor for reading a secret, such as a password. Each character typed
+// is shown as mask, or nothing is shown if mask is empty. Secrets are never added to the history or
+// completed.
+func NewSecretEditor(column, row, width int, mask string) *LineEditor {
+	e := NewLineEditor(column, row, width)
+	e.secret = true
+	e.mask = mask
+	return e
+}
+
+// ReadLine reads a line of input. If standard input is not a terminal the line is read as is,
+// without editing. If the terminal cannot be put into raw mode ErrInputFailure is returned, rather
+// than reading a line that would be echoed.
+func (e *LineEditor) ReadLine() (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return readPlainLine()
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", errs.ErrInputFailure.Wrap(err)
+	}
+	defer term.Restore(fd, state)
+
+	e.buf = buffer{}
+	e.offset = 0
+	e.pending = ""
+	if e.History != nil {
+		e.histPos = e.History.Len()
+	}
+	e.render()
+
+	for {
+		k, err := nextKey(e.Events, e.render)
+		if err != nil {
+			return "", err
+		}
+		switch k.key {
+		case keyEnter:
+			line := e.buf.String()
+			if e.secret {
+				e.buf.wipe()
+				return line, nil
+			}
+			if e.History != nil {
+				e.History.Add(line)
+			}
+			return line, nil
+		case keyInterrupt:
+			e.buf.wipe()
+			return "", errs.ErrInputInterrupted
+		case keyEscape:
+			e.buf.wipe()
+			return "", errs.ErrInputCancelled
+		case keyEOF:
+			if len(e.buf.text) == 0 {
+				return "", io.EOF
+			}
+			e.buf.delete()
+		case keyRune:
+			e.buf.insert(string(k.char))
+		case keyBackspace:
+			e.buf.backspace()
+		case keyDelete:
+			e.buf.delete()
+		case keyLeft:
+			e.buf.left()
+		case keyRight:
+			e.buf.right()
+		case keyHome:
+			e.buf.home()
+		case keyEnd:
+			e.buf.end()
+		case keyWordLeft:
+			e.buf.wordLeft()
+		case keyWordRight:
+			e.buf.wordRight()
+		case keyWordDelete:
+			e.buf.deleteWord()
+		case keyKillLine:
+			e.buf.killLine()
+		case keyKillToEnd:
+			e.buf.killToEnd()
+		case keyUp:
+			e.historyBack()
+		case keyDown:
+			e.historyForward()
+		case keyTab:
+			e.complete()
+		}
+		e.render()
+	}
+}
+
+// readPlainLine reads a line from standard input without any editing.
+func readPlainLine() (string, error) {
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// historyBack replaces the line with the previous history entry.
+func (e *LineEditor) historyBack() {
+	if e.History == nil || e.secret || e.histPos == 0 {
+		return
+	}
+	if e.histPos == e.History.Len() {
+		e.pending = e.buf.String()
+	}
+	e.histPos--
+	e.buf.set(e.History.Entries()[e.histPos])
+}
+
+// historyForward replaces the line with the next history entry, or the line being edited before
+// history was navigated.
+func (e *LineEditor) historyForward() {
+	if e.History == nil || e.secret || e.histPos >= e.History.Len() {
+		return
+	}
+	e.histPos++
+	if e.histPos == e.History.Len() {
+		e.buf.set(e.pending)
+		return
+	}
+	e.buf.set(e.History.Entries()[e.histPos])
+}
+
+// complete completes the word before the cursor. A single match replaces the word, several matches
+// extend it to their longest common prefix and are listed.
+func (e *LineEditor) complete() {
+	if e.secret {
+		return
+	}
+	word, before := e.buf.currentWord()
+	completer := e.Args
+	if len(before) == 0 {
+		completer = e.Commands
+	} else if e.ArgsFor != nil {
+		if c := e.ArgsFor(before[0], len(before)-1); c != nil {
+			completer = c
+		}
+	}
+	if completer == nil {
+		return
+	}
+	matches := completer.Complete(word)
+	switch len(matches) {
+	case 0:
+		return
+	case 1:
+		value := matches[0].Value
+		if !strings.HasSuffix(value, string(os.PathSeparator)) {
+			value = value + " "
+		}
+		e.buf.replaceWord(value)
+	default:
+		values := make([]string, len(matches))
+		for i, m := range matches {
+			values[i] = m.Value
+		}
+		if prefix := commonPrefix(values); len(prefix) > len(word) {
+			e.buf.replaceWord(prefix)
+		}
+		if e.List != nil {
+			e.List(matches)
+		}
+	}
+}
+
+// commonPrefix returns the longest prefix shared by all the given strings.
+func commonPrefix(list []string) string {
+	prefix := []rune(list[0])
+	for _, s := range list[1:] {
+		r := []rune(s)
+		i := 0
+		for i < len(prefix) && i < len(r) && prefix[i] == r[i] {
+			i++
+		}
+		prefix = prefix[:i]
+	}
+	return string(prefix)
+}
+
+// render draws the visible part of the line and places the cursor. The line scrolls sideways when it
+// is wider than the input, measured in screen columns.
+func (e *LineEditor) render() {
+	Screen.Lock()
+	defer Screen.Unlock()
+	if e.secret {
+		e.renderSecret()
+		return
+	}
+	if e.buf.cursor < e.offset {
+		e.offset = e.buf.cursor
+	}
+	for e.offset < e.buf.cursor && columns(e.buf.text[e.offset:e.buf.cursor]) >= e.width {
+		e.offset++
+	}
+	visible := e.buf.text[e.offset:]
+	line := wdth.Fit(string(visible), e.width)
+	cursor := columns(e.buf.text[e.offset:e.buf.cursor])
+	fmt.Fprintf(e.out, "\033[%d;%dH%s\033[%d;%dH", e.row, e.column, line, e.row, e.column+cursor)
+}
+
+// columns returns the number of screen columns occupied by the runes.
+func columns(runes []rune) int {
+	cols := 0
+	for _, r := range runes {
+		cols += wdth.Rune(r)
+	}
+	return cols
+}
+
+// renderSecret draws the mask for each character of a secret, or nothing at all if there is no mask.
+func (e *LineEditor) renderSecret() {
+	shown := 0
+	if e.mask != "" {
+		shown = len(e.buf.text)
+		if shown > e.width {
+			shown = e.width
+		}
+	}
+	line := strings.Repeat(e.mask, shown) + strings.Repeat(" ", e.width-shown)
+	fmt.Fprintf(e.out, "\033[%d;%dH%s\033[%d;%dH", e.row, e.column, line, e.row, e.column+shown)
+}
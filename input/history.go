@@ -0,0 +1,96 @@
+package input
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	supt "github.com/mt1976/crt/support"
+)
+
+// maxHistory is the number of entries kept in a history file.
+const maxHistory = 500
+
+// History is a list of previously entered lines, persisted to a file under the user's home directory.
+type History struct {
+	path    string
+	entries []string
+}
+
+// NewHistory returns the command history for the named application, loading any previously saved
+// entries. If the history file cannot be read the history starts empty, and is kept in memory only
+// if the file cannot be created.
+func NewHistory(app string) *History {
+	h := &History{}
+	home, err := supt.GetUserHome()
+	if err != nil || app == "" {
+		return h
+	}
+	h.path = filepath.Join(home, ".crt", "history", app)
+	h.load()
+	return h
+}
+
+// AppName returns the name of the running application, used to keep a separate history per
+// application.
+func AppName() string {
+	return strings.TrimSuffix(filepath.Base(os.Args[0]), filepath.Ext(os.Args[0]))
+}
+
+func (h *History) load() {
+	f, err := os.Open(h.path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	h.trim()
+}
+
+// Add appends a line to the history and saves it. Blank lines, and lines repeating the previous
+// entry, are not recorded.
+func (h *History) Add(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+	if n := len(h.entries); n > 0 && h.entries[n-1] == line {
+		return
+	}
+	h.entries = append(h.entries, line)
+	h.trim()
+	h.save()
+}
+
+// Entries returns the history, oldest first.
+func (h *History) Entries() []string {
+	return h.entries
+}
+
+// Len returns the number of entries in the history.
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+func (h *History) trim() {
+	if len(h.entries) > maxHistory {
+		h.entries = h.entries[len(h.entries)-maxHistory:]
+	}
+}
+
+func (h *History) save() {
+	if h.path == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return
+	}
+	content := strings.Join(h.entries, "\n") + "\n"
+	os.WriteFile(h.path, []byte(content), 0o600)
+}
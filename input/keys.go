@@ -0,0 +1,200 @@
+package input
+
+import (
+	"bufio"
+	"sync"
+	"unicode/utf8"
+)
+
+// key identifies a key, or key combination, read from the terminal.
+type key int
+
+const (
+	keyUnknown key = iota
+	keyRune
+	keyEnter
+	keyTab
+	keyBackspace
+	keyDelete
+	keyLeft
+	keyRight
+	keyUp
+	keyDown
+	keyHome
+	keyEnd
+	keyWordLeft
+	keyWordRight
+	keyWordDelete
+	keyKillLine
+	keyKillToEnd
+	keyEscape
+	keySave
+	keyInterrupt
+	keyEOF
+)
+
+// keyPress is a single key read from the terminal, along with the rune typed if it is printable.
+type keyPress struct {
+	key  key
+	char rune
+}
+
+// keyEvent is a key press read by readKeyAsync, or the error that stopped it reading.
+type keyEvent struct {
+	keyPress
+	err error
+}
+
+var (
+	keysLock sync.Mutex
+	keys     chan keyEvent // Receives the key press being read, nil when no read is in progress
+)
+
+// nextKey returns the next key press from the terminal. While it waits, it runs each function sent
+// on events, which may be nil, followed by after, so the screen can be changed and redrawn on the
+// goroutine reading input. Standard input is only read while nextKey is waiting, so nothing is read
+// once an editor has returned.
+func nextKey(events <-chan func(), after func()) (keyPress, error) {
+	if events == nil {
+		return readKey(stdin)
+	}
+	keysLock.Lock()
+	if keys == nil {
+		keys = make(chan keyEvent, 1)
+		go readKeyAsync(keys)
+	}
+	ch := keys
+	keysLock.Unlock()
+	for {
+		select {
+		case k := <-ch:
+			keysLock.Lock()
+			keys = nil
+			keysLock.Unlock()
+			return k.keyPress, k.err
+		case fn := <-events:
+			fn()
+			after()
+		}
+	}
+}
+
+// readKeyAsync reads a single key press from standard input and sends it on ch.
+func readKeyAsync(ch chan<- keyEvent) {
+	k, err := readKey(stdin)
+	ch <- keyEvent{k, err}
+}
+
+// readKey reads a single key press from the terminal, decoding ANSI/VT escape sequences.
+func readKey(r *bufio.Reader) (keyPress, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return keyPress{}, err
+	}
+	switch c {
+	case '\r', '\n':
+		return keyPress{key: keyEnter}, nil
+	case '\t':
+		return keyPress{key: keyTab}, nil
+	case 127, 8: // DEL, Ctrl-H
+		return keyPress{key: keyBackspace}, nil
+	case 1: // Ctrl-A
+		return keyPress{key: keyHome}, nil
+	case 2: // Ctrl-B
+		return keyPress{key: keyLeft}, nil
+	case 3: // Ctrl-C
+		return keyPress{key: keyInterrupt}, nil
+	case 4: // Ctrl-D
+		return keyPress{key: keyEOF}, nil
+	case 5: // Ctrl-E
+		return keyPress{key: keyEnd}, nil
+	case 6: // Ctrl-F
+		return keyPress{key: keyRight}, nil
+	case 11: // Ctrl-K
+		return keyPress{key: keyKillToEnd}, nil
+	case 14: // Ctrl-N
+		return keyPress{key: keyDown}, nil
+	case 16: // Ctrl-P
+		return keyPress{key: keyUp}, nil
+	case 19: // Ctrl-S
+		return keyPress{key: keySave}, nil
+	case 21: // Ctrl-U
+		return keyPress{key: keyKillLine}, nil
+	case 23: // Ctrl-W
+		return keyPress{key: keyWordDelete}, nil
+	case 27: // Escape
+		return readEscape(r)
+	}
+	if c < 32 || c == utf8.RuneError {
+		return keyPress{key: keyUnknown}, nil
+	}
+	return keyPress{key: keyRune, char: c}, nil
+}
+
+// readEscape decodes the remainder of an escape sequence. A lone escape, with nothing following it in
+// the buffer, is treated as the Escape key.
+func readEscape(r *bufio.Reader) (keyPress, error) {
+	if r.Buffered() == 0 {
+		return keyPress{key: keyEscape}, nil
+	}
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return keyPress{}, err
+	}
+	switch c {
+	case 'b': // Alt-B
+		return keyPress{key: keyWordLeft}, nil
+	case 'f': // Alt-F
+		return keyPress{key: keyWordRight}, nil
+	case 127: // Alt-Backspace
+		return keyPress{key: keyWordDelete}, nil
+	case '[', 'O':
+	default:
+		return keyPress{key: keyUnknown}, nil
+	}
+
+	// Control Sequence: parameters followed by a final byte in the range @ to ~
+	params := ""
+	for {
+		c, _, err = r.ReadRune()
+		if err != nil {
+			return keyPress{}, err
+		}
+		if c >= '@' && c <= '~' {
+			break
+		}
+		params = params + string(c)
+	}
+
+	modified := params == "1;5" || params == "1;3"
+	switch c {
+	case 'A':
+		return keyPress{key: keyUp}, nil
+	case 'B':
+		return keyPress{key: keyDown}, nil
+	case 'C':
+		if modified {
+			return keyPress{key: keyWordRight}, nil
+		}
+		return keyPress{key: keyRight}, nil
+	case 'D':
+		if modified {
+			return keyPress{key: keyWordLeft}, nil
+		}
+		return keyPress{key: keyLeft}, nil
+	case 'H':
+		return keyPress{key: keyHome}, nil
+	case 'F':
+		return keyPress{key: keyEnd}, nil
+	case '~':
+		switch params {
+		case "1", "7":
+			return keyPress{key: keyHome}, nil
+		case "4", "8":
+			return keyPress{key: keyEnd}, nil
+		case "3":
+			return keyPress{key: keyDelete}, nil
+		}
+	}
+	return keyPress{key: keyUnknown}, nil
+}
@@ -0,0 +1,208 @@
+package input
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	errs "github.com/mt1976/crt/errors"
+	wdth "github.com/mt1976/crt/strings/width"
+	"golang.org/x/term"
+)
+
+// TextArea edits multiple lines of text within a rectangular region of the screen. Lines are wrapped
+// at the width of the region, Enter starts a new line, Ctrl-S saves and Escape cancels.
+type TextArea struct {
+	column int           // The screen column of the left edge of the area
+	row    int           // The screen row of the top edge of the area
+	width  int           // The number of columns in the area
+	height int           // The number of rows in the area
+	Events <-chan func() // Functions run while waiting for a key, such as a redraw, may be nil
+	out    io.Writer     // Where the text is drawn
+	buf    buffer        // The text being edited, lines are separated by newlines
+	scroll int           // The first visual line shown
+}
+
+// span is the part of the text shown on one row of the area.
+type span struct {
+	start int
+	end   int
+}
+
+// NewTextArea returns a TextArea covering the region with its top left corner at column and row.
+func NewTextArea(column, row, width, height int) *TextArea {
+	return &TextArea{column: column, row: row, width: width, height: height, out: os.Stdout}
+}
+
+// SetText sets the text to be edited, one string per line.
+func (a *TextArea) SetText(lines []string) {
+	a.buf.set(strings.Join(lines, "\n"))
+}
+
+// Edit lets the user edit the text, returning the lines when they save. If the user cancels,
+// ErrInputCancelled is returned. If standard input is not a terminal, lines are read until a line
+// containing a single full stop, or the end of the input.
+func (a *TextArea) Edit() ([]string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return readPlainLines()
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, errs.ErrInputFailure.Wrap(err)
+	}
+	defer term.Restore(fd, state)
+
+	a.render()
+	for {
+		k, err := nextKey(a.Events, a.render)
+		if err != nil {
+			return nil, err
+		}
+		switch k.key {
+		case keySave:
+			return strings.Split(a.buf.String(), "\n"), nil
+		case keyEscape:
+			return nil, errs.ErrInputCancelled
+		case keyInterrupt:
+			return nil, errs.ErrInputInterrupted
+		case keyEnter:
+			a.buf.insert("\n")
+		case keyRune:
+			a.buf.insert(string(k.char))
+		case keyBackspace:
+			a.buf.backspace()
+		case keyDelete, keyEOF:
+			a.buf.delete()
+		case keyLeft:
+			a.buf.left()
+		case keyRight:
+			a.buf.right()
+		case keyWordLeft:
+			a.buf.wordLeft()
+		case keyWordRight:
+			a.buf.wordRight()
+		case keyWordDelete:
+			a.buf.deleteWord()
+		case keyUp:
+			a.moveLine(-1)
+		case keyDown:
+			a.moveLine(1)
+		case keyHome:
+			spans := a.layout()
+			a.buf.cursor = spans[a.cursorLine(spans)].start
+		case keyEnd:
+			spans := a.layout()
+			a.buf.cursor = spans[a.cursorLine(spans)].end
+		}
+		a.render()
+	}
+}
+
+// readPlainLines reads lines from standard input until a line containing a single full stop.
+func readPlainLines() ([]string, error) {
+	var lines []string
+	for {
+		line, err := readPlainLine()
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == "." {
+			return lines, nil
+		}
+		lines = append(lines, line)
+	}
+}
+
+// layout splits the text into the spans shown on each row, wrapping at a space where possible. One
+// column is kept free so the cursor can sit at the end of a full row.
+func (a *TextArea) layout() []span {
+	text := a.buf.text
+	width := a.width - 1
+	if width < 2 {
+		width = 2
+	}
+	var spans []span
+	start := 0
+	for {
+		end := start
+		for end < len(text) && text[end] != '\n' {
+			end++
+		}
+		for columns(text[start:end]) > width {
+			// find the last rune that fits, then break after the last space before it
+			fits := start
+			for cols := 0; fits < end && cols+wdth.Rune(text[fits]) <= width; fits++ {
+				cols += wdth.Rune(text[fits])
+			}
+			brk := fits
+			for i := fits; i > start; i-- {
+				if text[i-1] == ' ' {
+					brk = i
+					break
+				}
+			}
+			spans = append(spans, span{start, brk})
+			start = brk
+		}
+		spans = append(spans, span{start, end})
+		if end >= len(text) {
+			return spans
+		}
+		start = end + 1
+	}
+}
+
+// cursorLine returns the index of the span containing the cursor.
+func (a *TextArea) cursorLine(spans []span) int {
+	line := 0
+	for i, s := range spans {
+		if s.start <= a.buf.cursor {
+			line = i
+		}
+	}
+	return line
+}
+
+// moveLine moves the cursor up or down by the given number of rows, keeping its column if possible.
+func (a *TextArea) moveLine(by int) {
+	spans := a.layout()
+	line := a.cursorLine(spans)
+	target := line + by
+	if target < 0 || target >= len(spans) {
+		return
+	}
+	col := columns(a.buf.text[spans[line].start:a.buf.cursor])
+	a.buf.cursor = spans[target].start
+	for a.buf.cursor < spans[target].end && columns(a.buf.text[spans[target].start:a.buf.cursor+1]) <= col {
+		a.buf.cursor++
+	}
+}
+
+// render draws the visible rows of the text and places the cursor.
+func (a *TextArea) render() {
+	Screen.Lock()
+	defer Screen.Unlock()
+	spans := a.layout()
+	line := a.cursorLine(spans)
+	if line < a.scroll {
+		a.scroll = line
+	}
+	if line >= a.scroll+a.height {
+		a.scroll = line - a.height + 1
+	}
+	for i := 0; i < a.height; i++ {
+		content := ""
+		if a.scroll+i < len(spans) {
+			s := spans[a.scroll+i]
+			content = string(a.buf.text[s.start:s.end])
+		}
+		fmt.Fprintf(a.out, "\033[%d;%dH%s", a.row+i, a.column, wdth.Fit(content, a.width))
+	}
+	col := columns(a.buf.text[spans[line].start:a.buf.cursor])
+	fmt.Fprintf(a.out, "\033[%d;%dH", a.row+line-a.scroll, a.column+col)
+}
@@ -0,0 +1,46 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_layout(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  []string
+		width int
+		want  []string
+	}{
+		{"Short lines", []string{"one", "two"}, 10, []string{"one", "two"}},
+		{"Wraps at a space", []string{"the quick brown fox"}, 11, []string{"the quick ", "brown fox"}},
+		{"Long word is split", []string{"abcdefghijkl"}, 6, []string{"abcde", "fghij", "kl"}},
+		{"Blank line kept", []string{"a", "", "b"}, 10, []string{"a", "", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewTextArea(1, 1, tt.width, 5)
+			a.SetText(tt.text)
+			var got []string
+			for _, s := range a.layout() {
+				got = append(got, string(a.buf.text[s.start:s.end]))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("layout() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_moveLine(t *testing.T) {
+	a := NewTextArea(1, 1, 20, 5)
+	a.SetText([]string{"first line", "ab", "third line"})
+	a.moveLine(-1)
+	if a.buf.cursor != 13 {
+		t.Errorf("moveLine(-1) cursor = %v, want end of short line 13", a.buf.cursor)
+	}
+	a.moveLine(-1)
+	if a.buf.cursor != 2 {
+		t.Errorf("moveLine(-1) cursor = %v, want 2", a.buf.cursor)
+	}
+}
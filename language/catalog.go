@@ -0,0 +1,319 @@
+package language
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+	tmpl "github.com/mt1976/crt/language/template"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// English is the locale of the messages built into the package, used when there is no translation.
+const English = "en"
+
+var (
+	catalogLock sync.RWMutex
+	english     = map[string]string{}            // The built in messages, by ID
+	catalogs    = map[string]map[string]string{} // Translations, by locale and then ID
+	fromDir     = map[string]map[string]string{} // Translations loaded from the configuration's LocaleDir, by locale and then ID
+	chosen      string                           // The locale chosen with SetLocale, empty if none has been
+	configured  string                           // The locale given by the configuration or environment, empty until needed
+
+	loadLock  sync.Mutex
+	loaded    bool   // True once the configuration's LocaleDir has been loaded, until the configuration changes
+	loadedDir string // The LocaleDir last loaded
+	loadErr   error  // Why the catalogs in the LocaleDir last loaded could not all be loaded
+)
+
+func init() {
+	tmpl.Locale = Locale
+	// Choose the locale and read the catalogs again when the configuration changes
+	conf.OnChange(func(conf.Change) {
+		catalogLock.Lock()
+		configured = ""
+		catalogLock.Unlock()
+		loadLock.Lock()
+		loaded = false
+		loadLock.Unlock()
+	})
+}
+
+// register adds a built in message to the English catalog.
+func register(id, message string) {
+	catalogLock.Lock()
+	defer catalogLock.Unlock()
+	english[id] = message
+}
+
+// translate returns the message with the given ID in a locale, trying the locale's language on its
+// own if there is no translation for its region, such as fr for fr-ca. Translations from the
+// configuration's LocaleDir come before those added by the application.
+func translate(id, active string) (string, bool) {
+	catalogLock.RLock()
+	defer catalogLock.RUnlock()
+	for _, tag := range []string{active, baseLanguage(active)} {
+		if msg, ok := fromDir[tag][id]; ok {
+			return msg, true
+		}
+		if msg, ok := catalogs[tag][id]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// Locale returns the active locale. Until one is chosen with SetLocale, this is the Locale in the
+// configuration, or the locale given by the LC_ALL, LC_MESSAGES or LANG environment variables, or
+// English. The catalogs in the configuration's LocaleDir are loaded the first time it is called, and
+// again after the configuration changes.
+func Locale() string {
+	loadConfiguredCatalogs()
+	catalogLock.RLock()
+	active := chosen
+	if active == "" {
+		active = configured
+	}
+	catalogLock.RUnlock()
+	if active != "" {
+		return active
+	}
+
+	catalogLock.Lock()
+	defer catalogLock.Unlock()
+	if chosen != "" {
+		return chosen
+	}
+	if configured == "" {
+		configured = normalise(conf.Current().Locale)
+		if configured == "" {
+			configured = detectLocale(os.Getenv)
+		}
+	}
+	return configured
+}
+
+// LocaleChosen returns true if the active locale was chosen with SetLocale or by the configuration's
+// Locale, rather than taken from the environment.
+func LocaleChosen() bool {
+	catalogLock.RLock()
+	defer catalogLock.RUnlock()
+	return chosen != "" || normalise(conf.Current().Locale) != ""
+}
+
+// loadConfiguredCatalogs loads the catalogs in the configuration's LocaleDir, unless they have been
+// loaded since the configuration last changed, in place of those loaded from the LocaleDir before. It
+// returns the error from loading them, which LoadError also returns.
+func loadConfiguredCatalogs() error {
+	dir := conf.Current().LocaleDir
+	loadLock.Lock()
+	defer loadLock.Unlock()
+	if loaded && dir == loadedDir {
+		return loadErr
+	}
+	loaded, loadedDir, loadErr = true, dir, nil
+	found := map[string]map[string]string{}
+	if dir != "" {
+		found, loadErr = readCatalogs(dir)
+	}
+	catalogLock.Lock()
+	fromDir = found
+	catalogLock.Unlock()
+	return loadErr
+}
+
+// LoadError returns why the catalogs in the configuration's LocaleDir could not all be loaded, or nil
+// if they were, or no LocaleDir is given.
+func LoadError() error {
+	return loadConfiguredCatalogs()
+}
+
+// SetLocale sets the locale that text is shown in, such as fr or pt_BR, in place of the one given by
+// the configuration or environment.
+func SetLocale(tag string) {
+	catalogLock.Lock()
+	defer catalogLock.Unlock()
+	chosen = normalise(tag)
+	if chosen == "" {
+		chosen = English
+	}
+}
+
+// detectLocale returns the locale given by the environment, or English if none is set.
+func detectLocale(getenv func(string) string) string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if tag := normalise(getenv(name)); tag != "" {
+			return tag
+		}
+	}
+	return English
+}
+
+// normalise converts a locale such as fr_FR.UTF-8 or fr-FR@euro to the form used as a catalog key,
+// fr-fr. The C and POSIX locales are English.
+func normalise(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	switch tag {
+	case "C", "POSIX":
+		return English
+	}
+	return strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+}
+
+// baseLanguage returns the language of a locale without its region, such as fr for fr-ca.
+func baseLanguage(tag string) string {
+	base, _, _ := strings.Cut(tag, "-")
+	return base
+}
+
+// AddCatalog adds translations for a locale, by message ID, to any already loaded for it.
+func AddCatalog(tag string, messages map[string]string) {
+	catalogLock.Lock()
+	defer catalogLock.Unlock()
+	addMessages(catalogs, tag, messages)
+}
+
+// addMessages adds translations for a locale to the catalogs.
+func addMessages(to map[string]map[string]string, tag string, messages map[string]string) {
+	tag = normalise(tag)
+	if to[tag] == nil {
+		to[tag] = map[string]string{}
+	}
+	for id, msg := range messages {
+		to[tag][id] = msg
+	}
+}
+
+// LoadCatalog loads translations from a JSON, YAML or gettext .po file. The locale is taken from the
+// file name, such as fr.json, de_DE.yaml or messages.pt_BR.po. JSON and YAML files hold an object of
+// translations keyed by message ID; .po files use the message ID as the msgid.
+func LoadCatalog(path string) error {
+	tag, messages, err := readCatalog(path)
+	if err != nil {
+		return err
+	}
+	AddCatalog(tag, messages)
+	return nil
+}
+
+// readCatalog reads the translations in a file, returning the locale they are for.
+func readCatalog(path string) (string, map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	ext := filepath.Ext(path)
+	tag := strings.TrimSuffix(filepath.Base(path), ext)
+	if i := strings.LastIndex(tag, "."); i >= 0 {
+		tag = tag[i+1:]
+	}
+	messages := map[string]string{}
+	switch strings.ToLower(ext) {
+	case ".json":
+		err = json.Unmarshal(data, &messages)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &messages)
+	case ".po":
+		messages, err = parsePO(string(data))
+	default:
+		err = fmt.Errorf("unsupported file type %v", ext)
+	}
+	if err != nil {
+		return "", nil, errs.ErrInvalidCatalog.With(path).Wrap(err)
+	}
+	return tag, messages, nil
+}
+
+// LoadCatalogs loads every JSON, YAML and .po file in a directory with LoadCatalog. A file that can
+// not be loaded does not stop the others being loaded; the errors for all of them are returned.
+func LoadCatalogs(dir string) error {
+	found, err := readCatalogs(dir)
+	for tag, messages := range found {
+		AddCatalog(tag, messages)
+	}
+	return err
+}
+
+// readCatalogs reads the translations in every JSON, YAML and .po file in a directory, by locale and
+// then ID, along with the errors for any files that could not be read.
+func readCatalogs(dir string) (map[string]map[string]string, error) {
+	found := map[string]map[string]string{}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return found, err
+	}
+	var failed []error
+	for _, e := range entries {
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".json", ".yaml", ".yml", ".po":
+			tag, messages, err := readCatalog(filepath.Join(dir, e.Name()))
+			if err != nil {
+				failed = append(failed, err)
+				continue
+			}
+			addMessages(found, tag, messages)
+		}
+	}
+	return found, errors.Join(failed...)
+}
+
+// IDs returns the IDs of every built in message, in alphabetical order.
+func IDs() []string {
+	catalogLock.RLock()
+	defer catalogLock.RUnlock()
+	var ids []string
+	for id := range english {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Message returns the built in English message with the given ID.
+func Message(id string) (string, bool) {
+	catalogLock.RLock()
+	defer catalogLock.RUnlock()
+	msg, ok := english[id]
+	return msg, ok
+}
+
+// Locales returns the locales translations have been loaded for, in alphabetical order.
+func Locales() []string {
+	catalogLock.RLock()
+	defer catalogLock.RUnlock()
+	var tags []string
+	for tag := range catalogs {
+		tags = append(tags, tag)
+	}
+	for tag := range fromDir {
+		if catalogs[tag] == nil {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Translations returns the translations loaded for a locale, by message ID.
+func Translations(tag string) map[string]string {
+	catalogLock.RLock()
+	defer catalogLock.RUnlock()
+	out := map[string]string{}
+	for id, msg := range catalogs[normalise(tag)] {
+		out[id] = msg
+	}
+	for id, msg := range fromDir[normalise(tag)] {
+		out[id] = msg
+	}
+	return out
+}
@@ -0,0 +1,157 @@
+package language
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+)
+
+func Test_detectLocale(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"Nothing set", map[string]string{}, "en"},
+		{"LANG with encoding", map[string]string{"LANG": "fr_FR.UTF-8"}, "fr-fr"},
+		{"LC_ALL wins", map[string]string{"LANG": "fr_FR.UTF-8", "LC_ALL": "de_DE@euro"}, "de-de"},
+		{"C locale", map[string]string{"LANG": "C"}, "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := detectLocale(getenv); got != tt.want {
+				t.Errorf("detectLocale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parsePO(t *testing.T) {
+	data := `# French
+msgid ""
+msgstr ""
+"Language: fr\n"
+
+#: page.go
+msgid "paging"
+msgstr "Page %v sur "
+"%v"
+
+msgid "hint"
+msgstr ""
+
+msgctxt "menu"
+msgid "proceed"
+msgstr "Continuer"
+`
+	got, err := parsePO(data)
+	if err != nil {
+		t.Fatalf("parsePO() error = %v", err)
+	}
+	want := map[string]string{"paging": "Page %v sur %v", "proceed": "Continuer"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePO() = %v, want %v", got, want)
+	}
+}
+
+func Test_LoadCatalog(t *testing.T) {
+	defer SetLocale(Locale())
+	dir := t.TempDir()
+	files := map[string]string{
+		"fr.json":   `{"help_page_title": "Page d'aide", "file_chooser_description.1": "Choisissez le fichier"}`,
+		"de.yaml":   "help_page_title: Hilfeseite\n",
+		"es_MX.po":  "msgid \"help_page_title\"\nmsgstr \"Página de ayuda\"\n",
+		"notes.txt": "ignored",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := LoadCatalogs(dir); err != nil {
+		t.Fatalf("LoadCatalogs() error = %v", err)
+	}
+
+	tests := []struct {
+		locale string
+		text   *Text
+		want   string
+	}{
+		{"fr", HelpPageTitle, "Page d'aide"},
+		{"fr_CA.UTF-8", HelpPageTitle, "Page d'aide"},
+		{"de", HelpPageTitle, "Hilfeseite"},
+		{"es-MX", HelpPageTitle, "Página de ayuda"},
+		{"es", HelpPageTitle, "Help Page"},
+		{"fr", HelpFor, "Help for "},
+		{"fr", New("help_page_title"), "help_page_title"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale+" "+tt.want, func(t *testing.T) {
+			SetLocale(tt.locale)
+			if got := tt.text.Text(); got != tt.want {
+				t.Errorf("Text() = %q, want %q", got, tt.want)
+			}
+			if got := tt.text.Len(); got != len([]rune(tt.want)) {
+				t.Errorf("Len() = %v, want %v", got, len([]rune(tt.want)))
+			}
+		})
+	}
+
+	SetLocale("fr")
+	if got := FileChooserDescription.String()[1]; got != "Choisissez le fichier\n" {
+		t.Errorf("paragraph line = %q", got)
+	}
+}
+
+func Test_ConfiguredLocale(t *testing.T) {
+	catalogLock.Lock()
+	saved := chosen
+	chosen = ""
+	catalogLock.Unlock()
+	defer func() {
+		catalogLock.Lock()
+		chosen = saved
+		catalogLock.Unlock()
+	}()
+	t.Setenv("LC_ALL", "en_GB.UTF-8")
+	Locale()
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"cfg_test": `), 0o644)
+	os.WriteFile(filepath.Join(dir, "de.json"), []byte(`{"cfg_test": "Konfiguriert"}`), 0o644)
+	os.WriteFile(filepath.Join(dir, "terminal.env"), []byte("Locale=de\nLocaleDir="+dir+"\n"), 0o644)
+	if err := conf.Load(conf.Options{Paths: []string{dir}, UserPaths: []string{}, SystemPaths: []string{}}); err != nil {
+		t.Fatal(err)
+	}
+	defer conf.Load(conf.Options{Paths: []string{}, UserPaths: []string{}, SystemPaths: []string{}})
+
+	if got := Locale(); got != "de" {
+		t.Errorf("Locale() after loading the configuration = %v, want de", got)
+	}
+	text := NewWithID("cfg_test", "Configured")
+	if got := text.Text(); got != "Konfiguriert" {
+		t.Errorf("Text() = %v, want the translation from the configured LocaleDir", got)
+	}
+	if err := LoadError(); !errors.Is(err, errs.ErrInvalidCatalog) {
+		t.Errorf("LoadError() = %v, want ErrInvalidCatalog for the broken catalog", err)
+	}
+
+	// Catalogs from the LocaleDir used before are forgotten when it changes
+	other := t.TempDir()
+	os.WriteFile(filepath.Join(other, "terminal.env"), []byte("Locale=de\nLocaleDir="+other+"\n"), 0o644)
+	if err := conf.Load(conf.Options{Paths: []string{other}, UserPaths: []string{}, SystemPaths: []string{}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := text.Text(); got != "Configured" {
+		t.Errorf("Text() after changing LocaleDir = %v, want Configured", got)
+	}
+	if err := LoadError(); err != nil {
+		t.Errorf("LoadError() after changing LocaleDir = %v, want nil", err)
+	}
+}
@@ -1,27 +1,41 @@
 package language
 
 // Page - Paging
-var TxtPagingPrompt *Text = New("Choose (F)orward, (B)ack or (Q)uit")
+var TxtPagingPrompt *Text = NewWithID("paging_prompt", "Choose (F)orward, (B)ack or (Q)uit")
 
 // Support DatesTimes
 var (
-	OneWord            *Text = New("one")
+	OneWord            *Text = NewWithID("one_word", "one")
 	OneNumeric         *Text = New("1")
-	Minutes            *Text = New("minutes")
-	MinutesShort       *Text = New("mins")
-	Hour               *Text = New("hour")
-	HourShort          *Text = New("hr")
-	MillisecondsShort  *Text = New("ms")
-	ApplicationVersion *Text = New("StarTerm - Utilities 1.0 %s")
-	ApplicationName    *Text = New("StarTerm")
-	Error              *Text = New("ERROR ")
-	Info               *Text = New("INFO ")
-	Warning            *Text = New("WARNING ")
-	Success            *Text = New("SUCCESS ")
-	Hint               *Text = New("HINT ")
-	Paging             *Text = New("Page %v of %v")
-	MinMax             *Text = New("Min: %v Max: %v")
-	ValidActions       *Text = New("valid actions [%v]")
+	Minutes            *Text = NewWithID("minutes", "minutes")
+	MinutesShort       *Text = NewWithID("minutes_short", "mins")
+	Hour               *Text = NewWithID("hour", "hour")
+	HourShort          *Text = NewWithID("hour_short", "hr")
+	MillisecondsShort  *Text = NewWithID("milliseconds_short", "ms")
+	ApplicationVersion *Text = NewWithID("application_version", "StarTerm - Utilities 1.0 {version}")
+	ApplicationName    *Text = NewWithID("application_name", "StarTerm")
+	Error              *Text = NewWithID("error", "ERROR ")
+	Info               *Text = NewWithID("info", "INFO ")
+	Warning            *Text = NewWithID("warning", "WARNING ")
+	Fatal              *Text = NewWithID("fatal", "FATAL ")
+	Success            *Text = NewWithID("success", "SUCCESS ")
+	Hint               *Text = NewWithID("hint", "HINT ")
+	Paging             *Text = NewWithID("paging", "Page {page} of {pages}")
+	MinMax             *Text = NewWithID("min_max", "Min: {min} Max: {max}")
+	ValidActions       *Text = NewWithID("valid_actions", "valid actions [{actions}]")
+)
+
+// Relative times
+var (
+	TimeJustNow *Text = NewWithID("time_just_now", "just now")
+	TimeAgo     *Text = NewWithID("time_ago", "{when} ago")
+	TimeFromNow *Text = NewWithID("time_from_now", "in {when}")
+	TimeSeconds *Text = NewWithID("time_seconds", "{n, plural, one {# sec} other {# secs}}")
+	TimeMinutes *Text = NewWithID("time_minutes", "{n, plural, one {# min} other {# mins}}")
+	TimeHours   *Text = NewWithID("time_hours", "{n, plural, one {# hr} other {# hrs}}")
+	TimeDays    *Text = NewWithID("time_days", "{n, plural, one {# day} other {# days}}")
+	TimeMonths  *Text = NewWithID("time_months", "{n, plural, one {# month} other {# months}}")
+	TimeYears   *Text = NewWithID("time_years", "{n, plural, one {# year} other {# years}}")
 )
 
 var ApplicationHeader *Paragraph = NewParagraph([]string{
@@ -38,37 +52,39 @@ var (
 	LineConstructor       *Text = New("%s%s%s")
 	MACAddressConstructor *Text = New("%v:%v:%v:%v:%v:%v")
 	IPAddressConstructor  *Text = New("%v.%v.%v.%v")
-	MinMaxLength          *Text = New("Text Length Min: %v Max: %v")
-	Proceed               *Text = New("Proceed")
-	SetPrompt             *Text = New("Please set a prompt for the page")
+	MinMaxLength          *Text = NewWithID("min_max_length", "Text Length Min: {min} Max: {max}")
+	Proceed               *Text = NewWithID("proceed", "Proceed")
+	SetPrompt             *Text = NewWithID("set_prompt", "Please set a prompt for the page")
+	SecretConfirmPrompt   *Text = NewWithID("secret_confirm_prompt", "Please re-enter to confirm")
+	TextAreaHint          *Text = NewWithID("text_area_hint", "Ctrl-S to save, Esc to cancel")
 )
 
 // FileChooser
 var (
-	FileChooserTitle        *Text      = New("File Chooser")
-	FileChooserDescription  *Paragraph = NewParagraph([]string{"This menu shows the list of files available for maintenance.", "Select the file you wish to use. PLEASE BE CAREFUL!"})
-	FileChooserPrompt       *Text      = New("Choose a file to use")
-	FileChooserConfirmation *Text      = New("Choose (S)end or (Q)uit")
-	FileChooserUserName     *Text      = New("User Name")
-	FileChooserUserHome     *Text      = New("User Home")
-	FileChooserDirectory    *Text      = New("Directory")
-	FileChooserHeadType     *Text      = New("T")
-	FileChooserHeadName     *Text      = New("Name")
-	FileChooserHeadMode     *Text      = New("Mode")
-	FileChooserHeadModified *Text      = New("Modified")
-	FileChooserHeadSize     *Text      = New("Size")
-	FileChooserNotAvailable *Text      = New("N/A")
+	FileChooserTitle        *Text      = NewWithID("file_chooser_title", "File Chooser")
+	FileChooserDescription  *Paragraph = NewParagraphWithID("file_chooser_description", []string{"This menu shows the list of files available for maintenance.", "Select the file you wish to use. PLEASE BE CAREFUL!"})
+	FileChooserPrompt       *Text      = NewWithID("file_chooser_prompt", "Choose a file to use")
+	FileChooserConfirmation *Text      = NewWithID("file_chooser_confirmation", "Choose (S)end or (Q)uit")
+	FileChooserUserName     *Text      = NewWithID("file_chooser_user_name", "User Name")
+	FileChooserUserHome     *Text      = NewWithID("file_chooser_user_home", "User Home")
+	FileChooserDirectory    *Text      = NewWithID("file_chooser_directory", "Directory")
+	FileChooserHeadType     *Text      = NewWithID("file_chooser_head_type", "T")
+	FileChooserHeadName     *Text      = NewWithID("file_chooser_head_name", "Name")
+	FileChooserHeadMode     *Text      = NewWithID("file_chooser_head_mode", "Mode")
+	FileChooserHeadModified *Text      = NewWithID("file_chooser_head_modified", "Modified")
+	FileChooserHeadSize     *Text      = NewWithID("file_chooser_head_size", "Size")
+	FileChooserNotAvailable *Text      = NewWithID("file_chooser_not_available", "N/A")
 	//head, " ", "T", "Name", "Mode", "Modified", "Size"
 )
 
 // Help Messages
 var (
-	HelpPageTitle        *Text = New("Help Page")
-	HelpFor              *Text = New("Help for ")
-	HelpPromptSinglePage *Text = New("Choose (Y)es when done")
-	HelpPromptMultiPage  *Text = New("Choose (F)orward, (B)ack or (Y)es when done")
-	HelpSupportedActions *Text = New("The following actions are supported:")
-	HelpAutoGenerated    *Text = New("Autogenerated : ")
+	HelpPageTitle        *Text = NewWithID("help_page_title", "Help Page")
+	HelpFor              *Text = NewWithID("help_for", "Help for ")
+	HelpPromptSinglePage *Text = NewWithID("help_prompt_single_page", "Choose (Y)es when done")
+	HelpPromptMultiPage  *Text = NewWithID("help_prompt_multi_page", "Choose (F)orward, (B)ack or (Y)es when done")
+	HelpSupportedActions *Text = NewWithID("help_supported_actions", "The following actions are supported:")
+	HelpAutoGenerated    *Text = NewWithID("help_auto_generated", "Autogenerated : ")
 	//	HelpBullet           *Text = NewText("- ")
-	HelpHint *Text = New("Help:")
+	HelpHint *Text = NewWithID("help_hint", "Help:")
 )
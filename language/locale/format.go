@@ -0,0 +1,38 @@
+package locale
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tmpl "github.com/mt1976/crt/language/template"
+	"golang.org/x/text/currency"
+)
+
+// formatNumber is the template format for numbers, such as {n, number} or {n, number, 2}, written
+// with the locale's thousands separator and decimal mark.
+func formatNumber(v any, style, locale string) string {
+	n, ok := tmpl.Float(v)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	places := -1
+	if p, err := strconv.Atoi(style); err == nil {
+		places = p
+	}
+	return For(locale).Decimal(n, places)
+}
+
+// formatCurrency is the template format for amounts of money, such as {price, currency} in the
+// locale's currency or {price, currency, EUR} in a given one.
+func formatCurrency(v any, style, locale string) string {
+	n, ok := tmpl.Float(v)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	l := For(locale)
+	if unit, err := currency.ParseISO(strings.TrimSpace(style)); err == nil {
+		return l.Money(n, unit)
+	}
+	return l.Currency(n)
+}
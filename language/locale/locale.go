@@ -0,0 +1,261 @@
+// Package locale formats numbers, amounts of money, dates and times the way they are written in the
+// active locale, with the thousands separator, decimal mark, currency symbol, date order and
+// relative-time phrases that go with it.
+package locale
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+	lang "github.com/mt1976/crt/language"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// Order is the order the day, month and year are written in a date.
+type Order int
+
+const (
+	DMY Order = iota // Day, month, year, as in most of the world
+	MDY              // Month, day, year, as in the United States
+	YMD              // Year, month, day, as in China, Japan and Sweden
+)
+
+// Direction is the direction text is read in.
+type Direction int
+
+const (
+	LeftToRight Direction = iota
+	RightToLeft           // As in Arabic and Hebrew
+)
+
+// Locale describes how values are written in a locale.
+type Locale struct {
+	Tag            language.Tag  // The language and region
+	Direction      Direction     // The direction text is read in
+	Order          Order         // The order of the parts of a date
+	DateSeparator  string        // The separator between the parts of a short date
+	Clock12        bool          // Times use a 12 hour clock with AM and PM
+	Unit           currency.Unit // The local currency
+	SymbolAfter    bool          // The currency symbol follows the amount, as in 12,50 €
+	explicitRegion bool          // The region was given, rather than guessed from the language
+	chosen         bool          // The locale was chosen, rather than taken from the environment
+}
+
+// The regions that differ from the usual day, month, year order, or / separator, or 24 hour clock,
+// and the scripts that are written from right to left.
+var (
+	mdyRegions     = regions("US", "PH", "FM", "MH", "PW")
+	ymdRegions     = regions("CN", "JP", "KR", "TW", "HU", "LT", "MN", "SE", "CA")
+	dotRegions     = regions("DE", "AT", "CH", "RU", "PL", "FI", "NO", "DK", "CZ", "SK", "TR", "UA", "HU", "RO", "BG", "HR", "RS")
+	dashRegions    = regions("NL", "SE", "LT", "CA", "BE")
+	clock12Regions = regions("US", "PH", "AU", "NZ", "IN", "PK", "EG", "SA")
+	rtlScripts     = regions("Arab", "Hebr", "Thaa", "Syrc", "Nkoo", "Adlm", "Rohg")
+	symbolBefore   = regions("US", "GB", "IE", "AU", "NZ", "CA", "IN", "CN", "JP", "KR", "TW", "HK", "SG", "MX", "BR", "CH", "IL", "PH", "ZA")
+)
+
+func init() {
+	conf.AddCheck("Locale", func(c conf.Config) error {
+		tag, _, _ := strings.Cut(strings.TrimSpace(c.Locale), ".")
+		tag, _, _ = strings.Cut(tag, "@")
+		if tag == "" || tag == "C" || tag == "POSIX" {
+			return nil
+		}
+		if _, err := language.Parse(strings.ReplaceAll(tag, "_", "-")); err != nil {
+			return errs.ErrUnknownLocale.With(c.Locale).Wrap(err)
+		}
+		return nil
+	})
+}
+
+func regions(codes ...string) map[string]bool {
+	m := make(map[string]bool, len(codes))
+	for _, c := range codes {
+		m[c] = true
+	}
+	return m
+}
+
+// Current returns the active locale, as chosen by language.Locale.
+func Current() *Locale {
+	l := For(lang.Locale())
+	l.chosen = lang.LocaleChosen()
+	return l
+}
+
+// For returns the locale for a tag such as fr, en-GB or pt_BR. An unrecognised tag is English.
+func For(tag string) *Locale {
+	t, err := language.Parse(strings.ReplaceAll(tag, "_", "-"))
+	if err != nil {
+		t = language.English
+	}
+	region, confidence := t.Region()
+	code := region.String()
+	script, _ := t.Script()
+	l := &Locale{
+		Tag:            t,
+		Direction:      LeftToRight,
+		Order:          DMY,
+		DateSeparator:  "/",
+		Clock12:        clock12Regions[code],
+		SymbolAfter:    !symbolBefore[code],
+		explicitRegion: confidence == language.Exact,
+		chosen:         true,
+	}
+	if rtlScripts[script.String()] {
+		l.Direction = RightToLeft
+	}
+	switch {
+	case mdyRegions[code]:
+		l.Order = MDY
+	case ymdRegions[code]:
+		l.Order = YMD
+	}
+	switch {
+	case dotRegions[code]:
+		l.DateSeparator = "."
+	case dashRegions[code]:
+		l.DateSeparator = "-"
+	}
+	l.Unit, _ = currency.FromRegion(region)
+	if code := conf.Current().Currency; code != "" {
+		if unit, err := currency.ParseISO(code); err == nil {
+			l.Unit = unit
+		}
+	}
+	return l
+}
+
+// String returns the locale's tag, such as en-GB.
+func (l *Locale) String() string {
+	return l.Tag.String()
+}
+
+// RTL returns true if text in the locale is read from right to left, so layouts should be mirrored.
+func (l *Locale) RTL() bool {
+	return l.Direction == RightToLeft
+}
+
+// Printer returns a printer that formats numbers for the locale.
+func (l *Locale) Printer() *message.Printer {
+	return message.NewPrinter(l.Tag)
+}
+
+// Number formats a number with the locale's thousands separator and decimal mark. Whole numbers
+// have no decimal places and others have two.
+func (l *Locale) Number(v any) string {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return l.Printer().Sprintf("%d", number.Decimal(v))
+	case float32, float64:
+		return l.Printer().Sprintf("%.2f", number.Decimal(v))
+	}
+	return fmt.Sprint(v)
+}
+
+// Decimal formats a number with the given number of decimal places, or with as many as it needs if
+// places is negative.
+func (l *Locale) Decimal(v float64, places int) string {
+	if places < 0 {
+		places = 0
+		if _, frac, ok := strings.Cut(strconv.FormatFloat(v, 'f', -1, 64), "."); ok {
+			places = len(frac)
+		}
+	}
+	return l.Printer().Sprint(number.Decimal(v, number.MinFractionDigits(places), number.MaxFractionDigits(places)))
+}
+
+// Currency formats an amount in the locale's currency, such as $1,234.50 or 1.234,50 €.
+func (l *Locale) Currency(amount float64) string {
+	return l.Money(amount, l.Unit)
+}
+
+// Money formats an amount in the given currency, using the locale's numbers and symbol position.
+func (l *Locale) Money(amount float64, unit currency.Unit) string {
+	scale, _ := currency.Standard.Rounding(unit)
+	symbol := l.Printer().Sprint(currency.NarrowSymbol(unit))
+	value := l.Decimal(math.Abs(amount), scale)
+	sign := ""
+	if amount < 0 && value != l.Decimal(0, scale) {
+		sign = "-"
+	}
+	if l.SymbolAfter {
+		return sign + value + " " + symbol
+	}
+	return sign + symbol + value
+}
+
+// ownLayouts returns true if dates and times are written in the locale's own layouts, rather than
+// the configured ones. This is only so when the locale, with its region, was chosen by SetLocale, the
+// configuration's Locale or For, and not taken from the environment.
+func (l *Locale) ownLayouts() bool {
+	return l.chosen && l.explicitRegion
+}
+
+// ShortDateLayout returns the layout of a short date, such as 02/01/06. Unless the locale has its own
+// layouts the configured ApplicationDateFormatShort is used, if there is one.
+func (l *Locale) ShortDateLayout() string {
+	if layout := conf.Current().ApplicationDateFormatShort; !l.ownLayouts() && layout != "" {
+		return layout
+	}
+	s := l.DateSeparator
+	switch l.Order {
+	case MDY:
+		return "01" + s + "02" + s + "06"
+	case YMD:
+		if s == "-" {
+			return "2006-01-02"
+		}
+		return "06" + s + "01" + s + "02"
+	}
+	return "02" + s + "01" + s + "06"
+}
+
+// DateLayout returns the layout of a long date, such as 02 Jan 2006. Unless the locale has its own
+// layouts the configured ApplicationDateFormat is used, if there is one.
+func (l *Locale) DateLayout() string {
+	if layout := conf.Current().ApplicationDateFormat; !l.ownLayouts() && layout != "" {
+		return layout
+	}
+	switch l.Order {
+	case MDY:
+		return "Jan 02, 2006"
+	case YMD:
+		return "2006 Jan 02"
+	}
+	return "02 Jan 2006"
+}
+
+// TimeLayout returns the layout of a time, such as 15:04 or 3:04 PM. Unless the locale has its own
+// layouts the configured ApplicationTimeFormat is used, if there is one.
+func (l *Locale) TimeLayout() string {
+	if layout := conf.Current().ApplicationTimeFormat; !l.ownLayouts() && layout != "" {
+		return layout
+	}
+	if l.Clock12 {
+		return "3:04 PM"
+	}
+	return "15:04"
+}
+
+// ShortDate formats a date in the locale's short form.
+func (l *Locale) ShortDate(t time.Time) string {
+	return t.Format(l.ShortDateLayout())
+}
+
+// Date formats a date in the locale's long form.
+func (l *Locale) Date(t time.Time) string {
+	return t.Format(l.DateLayout())
+}
+
+// Time formats the time of day.
+func (l *Locale) Time(t time.Time) string {
+	return t.Format(l.TimeLayout())
+}
@@ -0,0 +1,140 @@
+package locale
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+)
+
+func Test_Formats(t *testing.T) {
+	date := time.Date(2024, time.January, 25, 15, 4, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		got  func(l *Locale) string
+		tag  string
+		want string
+	}{
+		{"Number in English", func(l *Locale) string { return l.Number(1234567) }, "en-GB", "1,234,567"},
+		{"Number in French", func(l *Locale) string { return l.Decimal(1234.5, 2) }, "fr-FR", "1\u00a0234,50"},
+		{"Number in German", func(l *Locale) string { return l.Number(1234.5) }, "de-DE", "1.234,50"},
+		{"Currency before", func(l *Locale) string { return l.Currency(-1234.5) }, "en-US", "-$1,234.50"},
+		{"Currency after", func(l *Locale) string { return l.Currency(1234.5) }, "de-DE", "1.234,50 €"},
+		{"Currency without decimals", func(l *Locale) string { return l.Currency(1234.5) }, "ja-JP", "￥1,234"},
+		{"Date in the UK", func(l *Locale) string { return l.ShortDate(date) }, "en-GB", "25/01/24"},
+		{"Date in the US", func(l *Locale) string { return l.ShortDate(date) }, "en-US", "01/25/24"},
+		{"Date in Germany", func(l *Locale) string { return l.ShortDate(date) }, "de-DE", "25.01.24"},
+		{"Date in Sweden", func(l *Locale) string { return l.ShortDate(date) }, "sv-SE", "2024-01-25"},
+		{"Time in the US", func(l *Locale) string { return l.Time(date) }, "en-US", "3:04 PM"},
+		{"Time in France", func(l *Locale) string { return l.Time(date) }, "fr-FR", "15:04"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got(For(tt.tag)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_RelativeTime(t *testing.T) {
+	now := time.Date(2024, time.January, 25, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		tag  string
+		then time.Time
+		want string
+	}{
+		{"Just now", "en", now.Add(-2 * time.Second), "just now"},
+		{"One minute", "en", now.Add(-time.Minute), "1 min ago"},
+		{"Minutes", "en", now.Add(-5 * time.Minute), "5 mins ago"},
+		{"Hours", "en", now.Add(-3 * time.Hour), "3 hrs ago"},
+		{"Future", "en", now.Add(48 * time.Hour), "in 2 days"},
+		{"French", "fr-FR", now.Add(-3 * 24 * time.Hour), "il y a 3 jours"},
+		{"German", "de", now.Add(-time.Hour), "vor 1 Std."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := For(tt.tag).RelativeTime(tt.then, now); got != tt.want {
+				t.Errorf("RelativeTime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Direction(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{"en-GB", false},
+		{"he", true},
+		{"he-IL", true},
+		{"ar-EG", true},
+		{"fa", true},
+		{"fr", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := For(tt.tag).RTL(); got != tt.want {
+				t.Errorf("RTL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_CurrentLayouts(t *testing.T) {
+	date := time.Date(2024, time.January, 25, 15, 4, 0, 0, time.UTC)
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+	defer conf.Load(conf.Options{Paths: []string{}, UserPaths: []string{}, SystemPaths: []string{}})
+
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{"Locale from the environment", "ApplicationDateFormatShort=2006/01/02\n", "2024/01/25"},
+		{"Locale in the configuration", "ApplicationDateFormatShort=2006/01/02\nLocale=en_US\n", "01/25/24"},
+		{"Locale without a region", "ApplicationDateFormatShort=2006/01/02\nLocale=en\n", "2024/01/25"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			os.WriteFile(filepath.Join(dir, "terminal.env"), []byte(tt.config), 0o644)
+			if err := conf.Load(conf.Options{Paths: []string{dir}, UserPaths: []string{}, SystemPaths: []string{}}); err != nil {
+				t.Fatalf("Load() = %v", err)
+			}
+			if got := Current().ShortDate(date); got != tt.want {
+				t.Errorf("ShortDate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ValidateLocale(t *testing.T) {
+	tests := []struct {
+		locale  string
+		wantErr error
+	}{
+		{"", nil},
+		{"en_GB.UTF-8", nil},
+		{"C", nil},
+		{"pt-BR", nil},
+		{"not a locale", errs.ErrUnknownLocale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			c := conf.Defaults()
+			c.Locale = tt.locale
+			if err := c.Validate(); !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("Validate() with Locale %q = %v, want %v", tt.locale, err, tt.wantErr)
+			}
+		})
+	}
+}
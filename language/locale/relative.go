@@ -0,0 +1,91 @@
+package locale
+
+import (
+	"time"
+
+	lang "github.com/mt1976/crt/language"
+	tmpl "github.com/mt1976/crt/language/template"
+)
+
+// builtin holds translations of the relative-time phrases for some common locales. Catalogs loaded
+// later replace them.
+var builtin = map[string]map[string]string{
+	"fr": {
+		"time_just_now": "à l'instant",
+		"time_ago":      "il y a {when}",
+		"time_from_now": "dans {when}",
+		"time_seconds":  "{n, plural, one {# s} other {# s}}",
+		"time_minutes":  "{n, plural, one {# min} other {# min}}",
+		"time_hours":    "{n, plural, one {# h} other {# h}}",
+		"time_days":     "{n, plural, one {# jour} other {# jours}}",
+		"time_months":   "{n, plural, one {# mois} other {# mois}}",
+		"time_years":    "{n, plural, one {# an} other {# ans}}",
+	},
+	"de": {
+		"time_just_now": "gerade eben",
+		"time_ago":      "vor {when}",
+		"time_from_now": "in {when}",
+		"time_seconds":  "{n, plural, one {# Sek.} other {# Sek.}}",
+		"time_minutes":  "{n, plural, one {# Min.} other {# Min.}}",
+		"time_hours":    "{n, plural, one {# Std.} other {# Std.}}",
+		"time_days":     "{n, plural, one {# Tag} other {# Tagen}}",
+		"time_months":   "{n, plural, one {# Monat} other {# Monaten}}",
+		"time_years":    "{n, plural, one {# Jahr} other {# Jahren}}",
+	},
+	"es": {
+		"time_just_now": "ahora mismo",
+		"time_ago":      "hace {when}",
+		"time_from_now": "dentro de {when}",
+		"time_seconds":  "{n, plural, one {# s} other {# s}}",
+		"time_minutes":  "{n, plural, one {# min} other {# min}}",
+		"time_hours":    "{n, plural, one {# h} other {# h}}",
+		"time_days":     "{n, plural, one {# día} other {# días}}",
+		"time_months":   "{n, plural, one {# mes} other {# meses}}",
+		"time_years":    "{n, plural, one {# año} other {# años}}",
+	},
+}
+
+func init() {
+	for tag, messages := range builtin {
+		lang.AddCatalog(tag, messages)
+	}
+	tmpl.RegisterFormat("number", formatNumber)
+	tmpl.RegisterFormat("currency", formatCurrency)
+}
+
+// The length of each unit of relative time, largest first, and the text that describes it.
+var units = []struct {
+	length time.Duration
+	text   *lang.Text
+}{
+	{365 * 24 * time.Hour, lang.TimeYears},
+	{30 * 24 * time.Hour, lang.TimeMonths},
+	{24 * time.Hour, lang.TimeDays},
+	{time.Hour, lang.TimeHours},
+	{time.Minute, lang.TimeMinutes},
+	{time.Second, lang.TimeSeconds},
+}
+
+// RelativeTime describes how long before or after now t is, in its largest whole unit, such as
+// "3 mins ago" or "in 2 days". Times within a few seconds of now are "just now".
+func (l *Locale) RelativeTime(t, now time.Time) string {
+	diff := now.Sub(t)
+	future := diff < 0
+	if future {
+		diff = -diff
+	}
+	if diff < 5*time.Second {
+		return lang.TimeJustNow.In(l.String())
+	}
+	when := ""
+	for _, u := range units {
+		if diff >= u.length {
+			when = u.text.FormatIn(l.String(), int64(diff/u.length))
+			break
+		}
+	}
+	if future {
+		return lang.TimeFromNow.FormatIn(l.String(), tmpl.Args{"when": when})
+	}
+	return lang.TimeAgo.FormatIn(l.String(), tmpl.Args{"when": when})
+}
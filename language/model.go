@@ -1,16 +1,19 @@
 package language
 
 import (
+	"fmt"
 	"strings"
 	"unicode"
 
+	tmpl "github.com/mt1976/crt/language/template"
 	symb "github.com/mt1976/crt/strings/symbols"
+	wdth "github.com/mt1976/crt/strings/width"
 )
 
 type Text struct {
 	// General
-	content string
-	len     int
+	id      string // The stable ID the text is translated by, empty if it is never translated
+	content string // The English text, used when there is no translation
 }
 
 type Paragraph struct {
@@ -18,10 +21,20 @@ type Paragraph struct {
 	len     int
 }
 
+// New returns text that is shown as given, whatever the locale.
 func New(message string) *Text {
 	return &Text{
 		content: message,
-		len:     len(message),
+	}
+}
+
+// NewWithID returns text that is translated into the active locale by its ID, showing the English
+// message if there is no translation. The ID and message are added to the English catalog.
+func NewWithID(id, message string) *Text {
+	register(id, message)
+	return &Text{
+		id:      id,
+		content: message,
 	}
 }
 
@@ -35,12 +48,58 @@ func NewParagraph(message []string) *Paragraph {
 	return para
 }
 
+// NewParagraphWithID returns a paragraph whose lines are translated by the ID followed by the line
+// number, such as file_chooser_description.1 for the second line.
+func NewParagraphWithID(id string, message []string) *Paragraph {
+	para := &Paragraph{
+		len: len(message),
+	}
+	for i, m := range message {
+		para.content = append(para.content, *NewWithID(fmt.Sprintf("%v.%v", id, i), m))
+	}
+	return para
+}
+
+// ID returns the stable ID the text is translated by, or an empty string if it is never translated.
+func (t *Text) ID() string {
+	return t.id
+}
+
+// Text returns the text in the active locale.
 func (t *Text) Text() string {
+	return t.In(Locale())
+}
+
+// In returns the text in the given locale, or in English if it has no translation.
+func (t *Text) In(locale string) string {
+	if t.id != "" {
+		if msg, ok := translate(t.id, normalise(locale)); ok {
+			return msg
+		}
+	}
 	return t.content
 }
 
+// Format returns the text in the active locale with its placeholders, such as {page}, filled in from
+// args. Arguments are either a single template.Args, giving values by name, or values bound to the
+// placeholders in the order they first appear.
+func (t *Text) Format(args ...any) string {
+	return tmpl.Format(t.Text(), args...)
+}
+
+// FormatIn returns the text in the given locale with its placeholders filled in from args, using the
+// locale's plural rules.
+func (t *Text) FormatIn(locale string, args ...any) string {
+	parsed, err := tmpl.Parse(t.In(locale))
+	if err != nil {
+		return tmpl.Format(t.In(locale), args...)
+	}
+	return parsed.ExecuteIn(locale, args...)
+}
+
+// Len returns the display width of the text in the active locale.
 func (t *Text) Len() int {
-	return t.len
+	return wdth.Of(t.Text())
 }
 
 func isMessageInt(message string) bool {
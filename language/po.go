@@ -0,0 +1,59 @@
+package language
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parsePO reads the translations from the contents of a gettext .po file, keyed by msgid. Comments,
+// contexts and untranslated entries are skipped, as is the header entry with an empty msgid. Only
+// the first form of a plural entry is used.
+func parsePO(data string) (map[string]string, error) {
+	messages := map[string]string{}
+	var id, str strings.Builder
+	var current *strings.Builder
+	flush := func() {
+		if id.Len() > 0 && str.Len() > 0 {
+			messages[id.String()] = str.String()
+		}
+		id.Reset()
+		str.Reset()
+	}
+	for n, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, `"`) {
+			keyword, rest, _ := strings.Cut(line, " ")
+			switch keyword {
+			case "msgctxt":
+				flush()
+				current = nil
+			case "msgid":
+				flush()
+				current = &id
+			case "msgstr", "msgstr[0]":
+				current = &str
+			case "msgid_plural":
+				current = nil
+			default:
+				if !strings.HasPrefix(keyword, "msgstr[") {
+					return nil, fmt.Errorf("line %v: unexpected %q", n+1, line)
+				}
+				current = nil
+			}
+			line = strings.TrimSpace(rest)
+		}
+		text, err := strconv.Unquote(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %v", n+1, err)
+		}
+		if current != nil {
+			current.WriteString(text)
+		}
+	}
+	flush()
+	return messages, nil
+}
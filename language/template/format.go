@@ -0,0 +1,168 @@
+package template
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// FormatFunc formats a value for a placeholder with the given style, such as the layout of a date,
+// in the given locale.
+type FormatFunc func(v any, style, locale string) string
+
+var (
+	formatsLock sync.RWMutex
+	formats     = map[string]FormatFunc{
+		"number":   formatNumber,
+		"percent":  formatPercent,
+		"date":     formatTime("2006-01-02"),
+		"time":     formatTime("15:04"),
+		"duration": formatDuration,
+		"upper":    func(v any, _, _ string) string { return strings.ToUpper(fmt.Sprint(v)) },
+		"lower":    func(v any, _, _ string) string { return strings.ToLower(fmt.Sprint(v)) },
+	}
+)
+
+// RegisterFormat adds a format that placeholders can use by name, such as {price, currency},
+// replacing any format already registered with the name.
+func RegisterFormat(name string, fn FormatFunc) {
+	formatsLock.Lock()
+	defer formatsLock.Unlock()
+	formats[strings.ToLower(name)] = fn
+}
+
+// formatValue formats a value with the named format or, if there is none, by its type.
+func formatValue(v any, format, style, locale string) string {
+	if format == "" {
+		switch v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			format = "number"
+		case time.Time:
+			format = "date"
+		case time.Duration:
+			format = "duration"
+		}
+	}
+	formatsLock.RLock()
+	fn, ok := formats[format]
+	formatsLock.RUnlock()
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	return fn(v, style, locale)
+}
+
+// Float returns a value as a float64, if it is a number or a string holding one.
+func Float(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// formatNumber formats a number with as many decimal places as it needs, or with the number of
+// places given as the style.
+func formatNumber(v any, style, _ string) string {
+	n, ok := Float(v)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	places := -1
+	if p, err := strconv.Atoi(style); err == nil {
+		places = p
+	}
+	return strconv.FormatFloat(n, 'f', places, 64)
+}
+
+// formatPercent formats a fraction, such as 0.25, as a whole percentage.
+func formatPercent(v any, _, _ string) string {
+	n, ok := Float(v)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	return strconv.FormatFloat(math.Round(n*100), 'f', 0, 64) + "%"
+}
+
+// formatTime returns a format for times, using the style as the layout, or the fallback layout.
+func formatTime(fallback string) FormatFunc {
+	return func(v any, style, _ string) string {
+		t, ok := v.(time.Time)
+		if !ok {
+			return fmt.Sprint(v)
+		}
+		if style == "" {
+			style = fallback
+		}
+		return t.Format(style)
+	}
+}
+
+// formatDuration formats a duration, rounded to the unit given as the style, such as s or ms.
+func formatDuration(v any, style, _ string) string {
+	d, ok := v.(time.Duration)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	if unit, err := time.ParseDuration("1" + style); err == nil && style != "" {
+		d = d.Round(unit)
+	}
+	return d.String()
+}
+
+// pluralKey returns the key of the branch of a plural placeholder to use for n. An exact match, such
+// as =0, is used first, then the locale's plural category for n, then other.
+func pluralKey(branches map[string][]node, n float64, locale string) string {
+	if _, ok := branches["="+strconv.FormatFloat(n, 'f', -1, 64)]; ok {
+		return "=" + strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	tag, err := language.Parse(locale)
+	if err != nil {
+		tag = language.English
+	}
+	digits := strconv.FormatFloat(math.Abs(n), 'f', -1, 64)
+	whole, fraction, _ := strings.Cut(digits, ".")
+	i, _ := strconv.Atoi(whole)
+	f, _ := strconv.Atoi("0" + fraction)
+	t, _ := strconv.Atoi("0" + strings.TrimRight(fraction, "0"))
+	form := plural.Cardinal.MatchPlural(tag, i, len(fraction), len(strings.TrimRight(fraction, "0")), f, t)
+	key := map[plural.Form]string{
+		plural.Zero: "zero", plural.One: "one", plural.Two: "two",
+		plural.Few: "few", plural.Many: "many", plural.Other: "other",
+	}[form]
+	if _, ok := branches[key]; ok {
+		return key
+	}
+	return "other"
+}
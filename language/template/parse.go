@@ -0,0 +1,140 @@
+package template
+
+import (
+	"errors"
+	"strings"
+)
+
+// parser reads a message one character at a time.
+type parser struct {
+	source string
+	pos    int
+}
+
+func (p *parser) peek() byte {
+	if p.pos >= len(p.source) {
+		return 0
+	}
+	return p.source[p.pos]
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.source) && strings.IndexByte(" \t\n", p.source[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// message parses text and placeholders up to the end of the source or, if nested, up to the closing
+// brace of the enclosing branch, which is consumed.
+func (p *parser) message(nested bool) ([]node, error) {
+	var nodes []node
+	var lit strings.Builder
+	flush := func() {
+		if lit.Len() > 0 {
+			nodes = append(nodes, text(lit.String()))
+			lit.Reset()
+		}
+	}
+	for p.pos < len(p.source) {
+		c := p.source[p.pos]
+		switch {
+		case c == '{' && strings.HasPrefix(p.source[p.pos:], "{{"):
+			lit.WriteByte('{')
+			p.pos += 2
+		case c == '}' && !nested && strings.HasPrefix(p.source[p.pos:], "}}"):
+			lit.WriteByte('}')
+			p.pos += 2
+		case c == '{':
+			flush()
+			p.pos++
+			ph, err := p.placeholder()
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, ph)
+		case c == '}':
+			if !nested {
+				return nil, errors.New("unexpected }")
+			}
+			p.pos++
+			flush()
+			return nodes, nil
+		default:
+			lit.WriteByte(c)
+			p.pos++
+		}
+	}
+	if nested {
+		return nil, errors.New("missing }")
+	}
+	flush()
+	return nodes, nil
+}
+
+// field reads up to the next comma or closing brace, which is not consumed.
+func (p *parser) field() string {
+	start := p.pos
+	for p.pos < len(p.source) && p.source[p.pos] != ',' && p.source[p.pos] != '}' {
+		p.pos++
+	}
+	return strings.TrimSpace(p.source[start:p.pos])
+}
+
+// placeholder parses the inside of a placeholder, after its opening brace, up to and including its
+// closing brace.
+func (p *parser) placeholder() (*placeholder, error) {
+	ph := &placeholder{name: p.field()}
+	if ph.name == "" {
+		return nil, errors.New("placeholder has no name")
+	}
+	if p.peek() == ',' {
+		p.pos++
+		ph.format = strings.ToLower(p.field())
+		if p.peek() == ',' {
+			p.pos++
+			if ph.format == "plural" || ph.format == "select" {
+				if err := p.branches(ph); err != nil {
+					return nil, err
+				}
+			} else {
+				ph.style = p.field()
+			}
+		}
+	}
+	if p.peek() != '}' {
+		return nil, errors.New("missing }")
+	}
+	p.pos++
+	if (ph.format == "plural" || ph.format == "select") && ph.branches["other"] == nil {
+		return nil, errors.New(ph.format + " has no other message")
+	}
+	return ph, nil
+}
+
+// branches parses the alternative messages of a plural or select placeholder, such as
+// one {# file} other {# files}, up to the placeholder's closing brace.
+func (p *parser) branches(ph *placeholder) error {
+	ph.branches = map[string][]node{}
+	for {
+		p.skipSpace()
+		if p.peek() == '}' || p.peek() == 0 {
+			return nil
+		}
+		start := p.pos
+		for p.pos < len(p.source) && strings.IndexByte(" \t\n{}", p.source[p.pos]) < 0 {
+			p.pos++
+		}
+		key := p.source[start:p.pos]
+		p.skipSpace()
+		if key == "" || p.peek() != '{' {
+			return errors.New("expected a key and a message in braces")
+		}
+		p.pos++
+		nodes, err := p.message(true)
+		if err != nil {
+			return err
+		}
+		ph.branches[key] = nodes
+		ph.order = append(ph.order, key)
+	}
+}
@@ -0,0 +1,203 @@
+// Package template formats messages with named placeholders, in the style of ICU message format.
+//
+// A placeholder is a name in braces, such as {file}. It may give a format, and a style for the
+// format, after commas, such as {size, number} or {when, date, 02 Jan 2006}. Plural and select
+// placeholders choose between alternative messages:
+//
+//	{count, plural, =0 {no files} one {# file} other {# files}}
+//	{state, select, up {running} other {stopped}}
+//
+// In a plural message, # is replaced by the number. Doubled braces, {{ and }}, are literal braces.
+package template
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Args holds the values for a template's placeholders by name.
+type Args map[string]any
+
+// Template is a parsed message.
+type Template struct {
+	source string
+	nodes  []node
+	names  []string
+}
+
+// node is a part of a parsed message.
+type node interface {
+	write(out *strings.Builder, c *context)
+}
+
+// text is literal text.
+type text string
+
+// placeholder is a named argument, with its format and style.
+type placeholder struct {
+	name     string
+	format   string
+	style    string
+	branches map[string][]node // The alternative messages of a plural or select placeholder
+	order    []string          // The keys of the branches, in the order given
+}
+
+// context holds the values a template is executed with.
+type context struct {
+	args   Args
+	locale string
+	hash   string // The number that replaces # inside a plural message
+}
+
+// cacheSize is the most parsed templates kept by Format. Messages are normally the built in
+// templates and catalog entries, so the cache is only emptied if it is given text that varies.
+const cacheSize = 1024
+
+var (
+	cacheLock sync.RWMutex
+	cache     = map[string]*Template{}
+)
+
+// Locale returns the locale templates are formatted for when none is given, such as en or fr-ca. It
+// is replaced by the language package with one that returns the active locale.
+var Locale = func() string { return "en" }
+
+// Parse parses a message.
+func Parse(source string) (*Template, error) {
+	p := &parser{source: source}
+	nodes, err := p.message(false)
+	if err != nil {
+		return nil, fmt.Errorf("%v at offset %v in %q", err, p.pos, source)
+	}
+	t := &Template{source: source, nodes: nodes}
+	t.names = collectNames(nodes, nil)
+	return t, nil
+}
+
+// Names returns the names of the template's placeholders in the order they first appear. Positional
+// arguments are bound to placeholders in this order.
+func (t *Template) Names() []string {
+	return t.names
+}
+
+// Source returns the message the template was parsed from.
+func (t *Template) Source() string {
+	return t.source
+}
+
+// Execute formats the template in the active locale. The arguments are either a single Args, giving
+// values by name, or values bound in turn to the placeholders in the order they first appear. Values
+// left over once every placeholder is bound are added to the end, separated by spaces.
+func (t *Template) Execute(args ...any) string {
+	return t.ExecuteIn(Locale(), args...)
+}
+
+// ExecuteIn formats the template in the given locale, as Execute does.
+func (t *Template) ExecuteIn(locale string, args ...any) string {
+	c := &context{args: Args{}, locale: locale}
+	var extra []any
+	if len(args) == 1 {
+		if named, ok := args[0].(Args); ok {
+			c.args = named
+			args = nil
+		}
+	}
+	for i, v := range args {
+		if i < len(t.names) {
+			c.args[t.names[i]] = v
+		} else {
+			extra = append(extra, v)
+		}
+	}
+	var out strings.Builder
+	for _, n := range t.nodes {
+		n.write(&out, c)
+	}
+	for _, v := range extra {
+		out.WriteString(" " + formatValue(v, "", "", locale))
+	}
+	return out.String()
+}
+
+// Format parses and executes a message, caching the parsed template. A message that cannot be parsed
+// is returned as it is, followed by the arguments. The message should be a template, such as a
+// catalog entry, not text that has already been formatted, which may hold braces of its own.
+func Format(source string, args ...any) string {
+	cacheLock.RLock()
+	t, ok := cache[source]
+	cacheLock.RUnlock()
+	if !ok {
+		var err error
+		if t, err = Parse(source); err != nil {
+			t = &Template{source: source, nodes: []node{text(source)}}
+		}
+		cacheLock.Lock()
+		if len(cache) >= cacheSize {
+			clear(cache)
+		}
+		cache[source] = t
+		cacheLock.Unlock()
+	}
+	return t.Execute(args...)
+}
+
+func (s text) write(out *strings.Builder, c *context) {
+	if c.hash != "" {
+		out.WriteString(strings.ReplaceAll(string(s), "#", c.hash))
+		return
+	}
+	out.WriteString(string(s))
+}
+
+func (p *placeholder) write(out *strings.Builder, c *context) {
+	v, ok := c.args[p.name]
+	if !ok {
+		out.WriteString("{" + p.name + "}")
+		return
+	}
+	switch p.format {
+	case "plural":
+		n, ok := Float(v)
+		if !ok {
+			out.WriteString(fmt.Sprint(v))
+			return
+		}
+		inner := *c
+		inner.hash = formatValue(v, "number", "", c.locale)
+		for _, branch := range p.branches[pluralKey(p.branches, n, c.locale)] {
+			branch.write(out, &inner)
+		}
+	case "select":
+		key := fmt.Sprint(v)
+		if _, ok := p.branches[key]; !ok {
+			key = "other"
+		}
+		for _, branch := range p.branches[key] {
+			branch.write(out, c)
+		}
+	default:
+		out.WriteString(formatValue(v, p.format, p.style, c.locale))
+	}
+}
+
+// collectNames adds the names of the placeholders in nodes to names, in order, without repeats.
+func collectNames(nodes []node, names []string) []string {
+	for _, n := range nodes {
+		p, ok := n.(*placeholder)
+		if !ok {
+			continue
+		}
+		found := false
+		for _, name := range names {
+			found = found || name == p.name
+		}
+		if !found {
+			names = append(names, p.name)
+		}
+		for _, key := range p.order {
+			names = collectNames(p.branches[key], names)
+		}
+	}
+	return names
+}
@@ -0,0 +1,69 @@
+package template
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test_Execute(t *testing.T) {
+	files := "{count, plural, =0 {no files} one {# file} other {# files}}"
+	tests := []struct {
+		name   string
+		source string
+		locale string
+		args   []any
+		want   string
+	}{
+		{"Named", "copied {file} to {dir}", "en", []any{Args{"dir": "/tmp", "file": "a.txt"}}, "copied a.txt to /tmp"},
+		{"Positional", "copied {file} to {dir}", "en", []any{"a.txt", "/tmp"}, "copied a.txt to /tmp"},
+		{"Repeated placeholder binds once", "{a}{b}{a}", "en", []any{"x", "y"}, "xyx"},
+		{"Extra values are appended", "not found {path}", "en", []any{"a", "b"}, "not found a b"},
+		{"Missing value is left in place", "copied {file}", "en", nil, "copied {file}"},
+		{"Plural exact", files, "en", []any{0}, "no files"},
+		{"Plural one", files, "en", []any{1}, "1 file"},
+		{"Plural other", files, "en", []any{3}, "3 files"},
+		{"Plural from a string", files, "en", []any{"1"}, "1 file"},
+		{"Plural few in Polish", "{n, plural, one {# plik} few {# pliki} many {# plików} other {# pliku}}", "pl", []any{3}, "3 pliki"},
+		{"Plural many in Polish", "{n, plural, one {# plik} few {# pliki} many {# plików} other {# pliku}}", "pl", []any{5}, "5 plików"},
+		{"Select", "{state, select, up {running} other {stopped}}", "en", []any{"down"}, "stopped"},
+		{"Number places", "{n, number, 2}", "en", []any{3.14159}, "3.14"},
+		{"Percent", "{n, percent}", "en", []any{0.25}, "25%"},
+		{"Date", "{d, date, 02 Jan 2006}", "en", []any{time.Date(2024, 1, 25, 9, 0, 0, 0, time.UTC)}, "25 Jan 2024"},
+		{"Duration by type", "took {d}", "en", []any{1500 * time.Millisecond}, "took 1.5s"},
+		{"Literal braces", "{{literal}} {x}", "en", []any{1}, "{literal} 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := Parse(tt.source)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := tmpl.ExecuteIn(tt.locale, tt.args...); got != tt.want {
+				t.Errorf("ExecuteIn() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ParseErrors(t *testing.T) {
+	for _, source := range []string{"{file", "file}", "{}", "{n, plural, one {# file}}", "{n, plural, other # files}"} {
+		if _, err := Parse(source); err == nil {
+			t.Errorf("Parse(%q) did not fail", source)
+		}
+	}
+	if got := Format("bad {message", "x"); got != "bad {message x" {
+		t.Errorf("Format() of a bad message = %q", got)
+	}
+}
+
+func Test_FormatCache(t *testing.T) {
+	for i := 0; i < cacheSize+10; i++ {
+		Format(fmt.Sprintf("message %v", i))
+	}
+	cacheLock.RLock()
+	defer cacheLock.RUnlock()
+	if len(cache) > cacheSize {
+		t.Errorf("Format() cached %v templates, want at most %v", len(cache), cacheSize)
+	}
+}
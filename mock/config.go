@@ -0,0 +1,44 @@
+package mock
+
+import (
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+)
+
+// Settings are the ranges random values are drawn from. They are read from the DefaultRandom
+// section of the configuration, such as DefaultRandomPortMin in an env file.
+type Settings struct {
+	PortMin int
+	PortMax int
+	MACMin  int
+	MACMax  int
+	IPMin   int
+	IPMax   int
+}
+
+// config is the mock section of the configuration.
+var config = conf.Register("DefaultRandom", Settings{PortMin: 1, PortMax: 65535, MACMin: 0, MACMax: 255, IPMin: 1, IPMax: 255}, validate)
+
+// validate checks that each range is in bounds and the right way round.
+func validate(s Settings) error {
+	ranges := []struct {
+		name          string
+		min, max      int
+		lowest, limit int
+	}{
+		{"Port", s.PortMin, s.PortMax, 1, 65535},
+		{"MAC", s.MACMin, s.MACMax, 0, 255},
+		{"IP", s.IPMin, s.IPMax, 0, 255},
+	}
+	for _, r := range ranges {
+		if r.min < r.lowest || r.max > r.limit || r.min > r.max {
+			return errs.ErrInvalidRandomRange.With(r.name, r.lowest, r.limit, r.min, r.max)
+		}
+	}
+	return nil
+}
+
+// Config returns the ranges random values are drawn from.
+func Config() Settings {
+	return config.Get()
+}
@@ -0,0 +1,42 @@
+package mock
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+	pflag "github.com/spf13/pflag"
+)
+
+func Test_Config(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	if err := conf.BindFlags(flags); err != nil {
+		t.Fatalf("BindFlags() = %v", err)
+	}
+	if err := flags.Parse([]string{"--default-random-port-max", "8080"}); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "terminal.env"), []byte("DefaultRandomPortMin=8000\nDefaultRandomIPMin=10\n"), 0o644)
+	if err := conf.Load(conf.Options{Paths: []string{dir}, UserPaths: []string{}, SystemPaths: []string{}, Flags: flags}); err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+	defer conf.Load(conf.Options{Paths: []string{}, UserPaths: []string{}, SystemPaths: []string{}})
+	if got := Config(); got.PortMin != 8000 || got.PortMax != 8080 || got.IPMin != 10 || got.IPMax != 255 {
+		t.Errorf("Config() = %+v, want ports 8000 to 8080 and IPs 10 to 255", got)
+	}
+	if got := conf.Current(); got.DefaultRandomPortMin != 8000 || got.DefaultRandomPortMax != 8080 || got.DefaultRandomIPMin != 10 {
+		t.Errorf("Current() DefaultRandom = %v to %v, IP from %v, want the section's values", got.DefaultRandomPortMin, got.DefaultRandomPortMax, got.DefaultRandomIPMin)
+	}
+
+	// A section with a setting named like one of the library's is reported rather than panicking
+	clash := conf.Register("Title", struct{ Length int }{}, nil)
+	t.Cleanup(clash.Unregister)
+	if err := conf.BindFlags(pflag.NewFlagSet("test", pflag.ContinueOnError)); !errors.Is(err, errs.ErrDuplicateSetting) {
+		t.Errorf("BindFlags() with a clash = %v, want ErrDuplicateSetting", err)
+	}
+}
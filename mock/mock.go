@@ -4,12 +4,9 @@ import (
 	"fmt"
 	"math/rand"
 
-	conf "github.com/mt1976/crt/config"
 	lang "github.com/mt1976/crt/language"
 )
 
-var config = conf.Configuration
-
 // The randomIP function generates a random IP address in IPv4 format.
 func RandomIP() string {
 	// Generate a random IP address in ipv4 format
@@ -23,10 +20,10 @@ func RandomIP() string {
 	// 	ip := randomIP()
 	// 	fmt.Println(ip)
 	//
-	ip1 := RandomNumber(config.DefaultRandomIPMin, config.DefaultRandomIPMax)
-	ip2 := RandomNumber(config.DefaultRandomIPMin, config.DefaultRandomIPMax)
-	ip3 := RandomNumber(config.DefaultRandomIPMin, config.DefaultRandomIPMax)
-	ip4 := RandomNumber(config.DefaultRandomIPMin, config.DefaultRandomIPMax)
+	ip1 := RandomNumber(config.Get().IPMin, config.Get().IPMax)
+	ip2 := RandomNumber(config.Get().IPMin, config.Get().IPMax)
+	ip3 := RandomNumber(config.Get().IPMin, config.Get().IPMax)
+	ip4 := RandomNumber(config.Get().IPMin, config.Get().IPMax)
 
 	return fmt.Sprintf(lang.IPAddressConstructor.Text(), ip1, ip2, ip3, ip4)
 }
@@ -42,12 +39,12 @@ func RandomMAC() string {
 	// 	mac := randomMAC()
 	// 	fmt.Println(mac)
 	//
-	mac1 := fmt.Sprintf("%02x", RandomNumber(config.DefaultRandomMACMin, config.DefaultRandomMACMax))
-	mac2 := fmt.Sprintf("%02x", RandomNumber(config.DefaultRandomMACMin, config.DefaultRandomMACMax))
-	mac3 := fmt.Sprintf("%02x", RandomNumber(config.DefaultRandomMACMin, config.DefaultRandomMACMax))
-	mac4 := fmt.Sprintf("%02x", RandomNumber(config.DefaultRandomMACMin, config.DefaultRandomMACMax))
-	mac5 := fmt.Sprintf("%02x", RandomNumber(config.DefaultRandomMACMin, config.DefaultRandomMACMax))
-	mac6 := fmt.Sprintf("%02x", RandomNumber(config.DefaultRandomMACMin, config.DefaultRandomMACMax))
+	mac1 := fmt.Sprintf("%02x", RandomNumber(config.Get().MACMin, config.Get().MACMax))
+	mac2 := fmt.Sprintf("%02x", RandomNumber(config.Get().MACMin, config.Get().MACMax))
+	mac3 := fmt.Sprintf("%02x", RandomNumber(config.Get().MACMin, config.Get().MACMax))
+	mac4 := fmt.Sprintf("%02x", RandomNumber(config.Get().MACMin, config.Get().MACMax))
+	mac5 := fmt.Sprintf("%02x", RandomNumber(config.Get().MACMin, config.Get().MACMax))
+	mac6 := fmt.Sprintf("%02x", RandomNumber(config.Get().MACMin, config.Get().MACMax))
 
 	return fmt.Sprintf(lang.MACAddressConstructor.Text(), mac1, mac2, mac3, mac4, mac5, mac6)
 }
@@ -63,7 +60,7 @@ func RandomPort() int {
 	// 	port := randomPort()
 	// 	fmt.Println(port)
 	//
-	return RandomNumber(config.DefaultRandomPortMin, config.DefaultRandomPortMax)
+	return RandomNumber(config.Get().PortMin, config.Get().PortMax)
 }
 
 // The randomNumber function generates a random number within a given range.
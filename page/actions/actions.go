@@ -14,4 +14,7 @@ var (
 	UpArrow     *Action = New("^")
 	Go          *Action = New("G")
 	Select      *Action = New("S")
+	Sort        *Action = New("SORT")
+	Find        *Action = New("FIND")
+	ChangeDir   *Action = New("CD")
 )
@@ -0,0 +1,242 @@
+package actions
+
+import (
+	"path/filepath"
+	"strings"
+
+	errs "github.com/mt1976/crt/errors"
+	inpt "github.com/mt1976/crt/input"
+	numb "github.com/mt1976/crt/numbers"
+	symb "github.com/mt1976/crt/strings/symbols"
+)
+
+// ArgKind identifies the type of value an argument accepts.
+type ArgKind int
+
+const (
+	StringArg  ArgKind = iota // Any single token
+	IntArg                    // A whole number
+	ChoiceArg                 // One of a fixed list of values
+	PatternArg                // A glob pattern, such as *.log
+)
+
+// Arg describes a single argument accepted by an Action.
+type Arg struct {
+	name     string
+	kind     ArgKind
+	required bool
+	fallback string
+	choices  []string
+	complete inpt.Completer // Offers values for the argument at the prompt, may be nil
+}
+
+// Args holds the parsed argument values for an Action, keyed by argument name.
+type Args struct {
+	values map[string]string
+	order  []string
+}
+
+// Handler is called with the parsed arguments when an Action is chosen.
+type Handler func(args *Args) error
+
+// NewArg returns a new, required, argument of the given kind.
+func NewArg(name string, kind ArgKind) *Arg {
+	return &Arg{name: name, kind: kind, required: true}
+}
+
+// Optional marks the argument as optional, using fallback when no value is given.
+func (a *Arg) Optional(fallback string) *Arg {
+	a.required = false
+	a.fallback = fallback
+	return a
+}
+
+// Choices sets the list of values accepted by a ChoiceArg.
+func (a *Arg) Choices(choices ...string) *Arg {
+	a.kind = ChoiceArg
+	a.choices = choices
+	return a
+}
+
+// CompletedBy sets the completer offering values for the argument when Tab is pressed at the
+// prompt, such as a filechooser.PathCompleter for a path.
+func (a *Arg) CompletedBy(completer inpt.Completer) *Arg {
+	a.complete = completer
+	return a
+}
+
+// Completer returns the completer for the argument: the one set by CompletedBy, or the choices of a
+// ChoiceArg, or nil if there is neither.
+func (a *Arg) Completer() inpt.Completer {
+	switch {
+	case a.complete != nil:
+		return a.complete
+	case a.kind == ChoiceArg:
+		return inpt.Words(a.choices...)
+	}
+	return nil
+}
+
+// Name returns the name of the argument.
+func (a *Arg) Name() string {
+	return a.name
+}
+
+// Usage returns the argument as it should be shown in help, e.g. <item> or [order:asc|desc].
+func (a *Arg) Usage() string {
+	desc := a.name
+	switch a.kind {
+	case IntArg:
+		desc = desc + ":n"
+	case ChoiceArg:
+		desc = desc + ":" + strings.Join(a.choices, "|")
+	case PatternArg:
+		desc = desc + ":*"
+	}
+	if a.required {
+		return "<" + desc + ">"
+	}
+	return "[" + desc + "]"
+}
+
+// validate checks that value is acceptable for the argument, returning the value to store.
+func (a *Arg) validate(value string) (string, error) {
+	switch a.kind {
+	case IntArg:
+		if !numb.IsInt(value) {
+			return "", errs.ErrInvalidArgument
+		}
+	case ChoiceArg:
+		for _, c := range a.choices {
+			if strings.EqualFold(c, value) {
+				return c, nil
+			}
+		}
+		return "", errs.ErrInvalidArgument
+	case PatternArg:
+		if _, err := filepath.Match(value, ""); err != nil {
+			return "", errs.ErrInvalidArgument
+		}
+	}
+	return value, nil
+}
+
+// WithArgs declares the arguments accepted by the action, in the order they must be given.
+func (a *Action) WithArgs(args ...*Arg) *Action {
+	a.args = args
+	return a
+}
+
+// WithHandler sets the function called with the parsed arguments when the action is chosen.
+func (a *Action) WithHandler(handler Handler) *Action {
+	a.handler = handler
+	return a
+}
+
+// HasArgs returns true if the action accepts arguments.
+func (a *Action) HasArgs() bool {
+	return len(a.args) > 0
+}
+
+// Handler returns the handler for the action, or nil if none has been set.
+func (a *Action) Handler() Handler {
+	return a.handler
+}
+
+// Args returns the parsed arguments attached to the action, or an empty set if there are none.
+func (a *Action) Args() *Args {
+	if a.values == nil {
+		return newArgs()
+	}
+	return a.values
+}
+
+// ArgCompleter returns the completer for the argument at index, counting from 0, or nil if the
+// action has no such argument or it has no completer.
+func (a *Action) ArgCompleter(index int) inpt.Completer {
+	if index < 0 || index >= len(a.args) {
+		return nil
+	}
+	return a.args[index].Completer()
+}
+
+// Usage returns the action and its arguments as they should be shown in help.
+func (a *Action) Usage() string {
+	out := []string{upcase(a.content)}
+	for _, arg := range a.args {
+		out = append(out, arg.Usage())
+	}
+	return strings.Join(out, symb.Space.Symbol())
+}
+
+// Parse validates the supplied parameters against the action's argument schema and returns a copy
+// of the action with the parsed arguments attached.
+func (a *Action) Parse(params []string) (*Action, error) {
+	if len(params) > len(a.args) {
+		return nil, errs.ErrTooManyArguments.With(a.Usage())
+	}
+	values := newArgs()
+	for i, arg := range a.args {
+		if i >= len(params) {
+			if arg.required {
+				return nil, errs.ErrMissingArgument.With(a.Usage())
+			}
+			values.set(arg.name, arg.fallback)
+			continue
+		}
+		v, err := arg.validate(params[i])
+		if err != nil {
+			return nil, errs.ErrInvalidArgument.With(a.Usage())
+		}
+		values.set(arg.name, v)
+	}
+	rtn := *a
+	rtn.values = values
+	return &rtn, nil
+}
+
+// SplitCommand splits an input line into the action name and its parameters.
+func SplitCommand(line string) (string, []string) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return fields[0], fields[1:]
+}
+
+func newArgs() *Args {
+	return &Args{values: make(map[string]string)}
+}
+
+func (a *Args) set(name, value string) {
+	if _, ok := a.values[name]; !ok {
+		a.order = append(a.order, name)
+	}
+	a.values[name] = value
+}
+
+// Has returns true if a value is present for the named argument.
+func (a *Args) Has(name string) bool {
+	v, ok := a.values[name]
+	return ok && v != ""
+}
+
+// String returns the value of the named argument.
+func (a *Args) String(name string) string {
+	return a.values[name]
+}
+
+// Int returns the value of the named argument as an integer, or 0 if it is not a number.
+func (a *Args) Int(name string) int {
+	return toInt(a.values[name])
+}
+
+// Len returns the number of arguments held.
+func (a *Args) Len() int {
+	return len(a.order)
+}
+
+// Names returns the argument names in the order they were declared.
+func (a *Args) Names() []string {
+	return a.order
+}
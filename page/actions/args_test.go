@@ -0,0 +1,119 @@
+package actions
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	errs "github.com/mt1976/crt/errors"
+	inpt "github.com/mt1976/crt/input"
+)
+
+func Test_Parse(t *testing.T) {
+	sortBy := New("SORT").WithArgs(
+		NewArg("field", ChoiceArg).Choices("name", "size"),
+		NewArg("order", ChoiceArg).Choices("asc", "desc").Optional("asc"),
+	)
+	goTo := New("G").WithArgs(NewArg("item", IntArg))
+	find := New("FIND").WithArgs(NewArg("pattern", PatternArg))
+
+	tests := []struct {
+		name    string
+		action  *Action
+		line    string
+		wantErr error
+		want    map[string]string
+	}{
+		{"Int argument", goTo, "G 12", nil, map[string]string{"item": "12"}},
+		{"Int argument not a number", goTo, "G twelve", errs.ErrInvalidArgument, nil},
+		{"Missing argument", goTo, "G", errs.ErrMissingArgument, nil},
+		{"Too many arguments", goTo, "G 1 2", errs.ErrTooManyArguments, nil},
+		{"Choice with default", sortBy, "SORT name", nil, map[string]string{"field": "name", "order": "asc"}},
+		{"Choice is case insensitive", sortBy, "sort SIZE Desc", nil, map[string]string{"field": "size", "order": "desc"}},
+		{"Choice not in list", sortBy, "SORT colour", errs.ErrInvalidArgument, nil},
+		{"Pattern", find, "FIND *.log", nil, map[string]string{"pattern": "*.log"}},
+		{"Bad pattern", find, "FIND [", errs.ErrInvalidArgument, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, params := SplitCommand(tt.line)
+			got, err := tt.action.Parse(params)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), tt.action.Usage()) {
+					t.Errorf("Parse() error = %v, want the usage %v", err, tt.action.Usage())
+				}
+				return
+			}
+			for k, v := range tt.want {
+				if got.Args().String(k) != v {
+					t.Errorf("Parse() %v = %v, want %v", k, got.Args().String(k), v)
+				}
+			}
+			if !got.Is(tt.action) {
+				t.Errorf("Parse() returned a different action")
+			}
+		})
+	}
+}
+
+func Test_Usage(t *testing.T) {
+	tests := []struct {
+		name   string
+		action *Action
+		want   string
+	}{
+		{"No arguments", New("q"), "Q"},
+		{"Required", New("G").WithArgs(NewArg("item", IntArg)), "G <item:n>"},
+		{"Optional", New("SORT").WithArgs(NewArg("order", ChoiceArg).Choices("asc", "desc").Optional("asc")), "SORT [order:asc|desc]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.action.Usage(); got != tt.want {
+				t.Errorf("Usage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ArgCompleter(t *testing.T) {
+	paths := inpt.Words("docs/", "downloads/")
+	sortBy := New("SORT").WithArgs(
+		NewArg("field", ChoiceArg).Choices("name", "size"),
+		NewArg("path", StringArg).CompletedBy(paths),
+		NewArg("pattern", PatternArg),
+	)
+
+	tests := []struct {
+		name  string
+		index int
+		word  string
+		want  []string
+	}{
+		{"Choices", 0, "s", []string{"size"}},
+		{"Completer", 1, "do", []string{"docs/", "downloads/"}},
+		{"No completer", 2, "", nil},
+		{"No such argument", 3, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := sortBy.ArgCompleter(tt.index)
+			if c == nil {
+				if tt.want != nil {
+					t.Fatalf("ArgCompleter(%v) = nil, want a completer", tt.index)
+				}
+				return
+			}
+			var got []string
+			for _, candidate := range c.Complete(tt.word) {
+				got = append(got, candidate.Value)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ArgCompleter(%v).Complete(%q) = %v, want %v", tt.index, tt.word, got, tt.want)
+			}
+		})
+	}
+}
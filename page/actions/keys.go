@@ -0,0 +1,115 @@
+package actions
+
+import (
+	"sort"
+	"strings"
+
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+	numb "github.com/mt1976/crt/numbers"
+)
+
+// bindable holds the built in actions whose keys can be changed, by name.
+var bindable = map[string]*Action{
+	"yes":     Yes,
+	"no":      No,
+	"quit":    Quit,
+	"forward": Forward,
+	"back":    Back,
+	"exit":    Exit,
+	"help":    Help,
+	"up":      Up,
+	"go":      Go,
+	"select":  Select,
+	"sort":    Sort,
+	"find":    Find,
+	"cd":      ChangeDir,
+}
+
+// fixed holds the keys of built in actions that can not be changed, with the name of the bindable
+// action they stand in for, so that no other action is bound to them.
+var fixed = map[string]string{
+	UpDoubleDot.content: "up",
+	UpArrow.content:     "up",
+}
+
+func init() {
+	conf.AddCheck("KeyBindings", func(c conf.Config) error {
+		// Bindings that are not an action and a key are reported by Validate itself
+		if _, err := conf.ParseKeyBindings(c.KeyBindings); err != nil {
+			return nil
+		}
+		_, err := ParseKeys(c.KeyBindings)
+		return err
+	})
+}
+
+// defaultKeys holds the keys the bindable actions start with, by name.
+var defaultKeys = func() map[string]string {
+	keys := make(map[string]string, len(bindable))
+	for name, a := range bindable {
+		keys[name] = a.content
+	}
+	return keys
+}()
+
+// ParseKeys reads key bindings such as "Quit:X, Forward:N", returning the key bound to each named
+// action. Names are not case sensitive. It is an error to name an action that can not be rebound, to
+// bind a number, which chooses a menu item, or to leave two actions with the same key, including the
+// keys of actions that can not be rebound.
+func ParseKeys(bindings string) (map[string]string, error) {
+	keys, err := conf.ParseKeyBindings(bindings)
+	if err != nil {
+		return nil, err
+	}
+	for name, key := range keys {
+		if _, known := bindable[name]; !known || numb.IsInt(key) {
+			return nil, errs.ErrInvalidKeyBinding.With(name + ":" + key)
+		}
+	}
+
+	names := make([]string, 0, len(bindable))
+	for name := range bindable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	used := map[string]string{}
+	for key, name := range fixed {
+		used[key] = name
+	}
+	for _, name := range names {
+		key, ok := keys[name]
+		if !ok {
+			key = defaultKeys[name]
+		}
+		if other, taken := used[strings.ToUpper(key)]; taken {
+			return nil, errs.ErrDuplicateKeyBinding.With(key, other, name)
+		}
+		used[strings.ToUpper(key)] = name
+	}
+	return keys, nil
+}
+
+// Bind sets the keys of the built in actions from bindings, as read by ParseKeys. Actions that are
+// not named go back to their default keys. Bind should be called from the goroutine reading input,
+// as it changes the actions that input is matched against.
+func Bind(bindings string) error {
+	keys, err := ParseKeys(bindings)
+	if err != nil {
+		return err
+	}
+	for name, a := range bindable {
+		key, ok := keys[name]
+		if !ok {
+			key = defaultKeys[name]
+		}
+		a.rebind(key)
+	}
+	return nil
+}
+
+// rebind changes the key that chooses the action, keeping its arguments and handler.
+func (a *Action) rebind(key string) {
+	b := New(key)
+	a.content, a.len, a.isNum = b.content, b.len, b.isNum
+}
@@ -0,0 +1,64 @@
+package actions
+
+import (
+	"errors"
+	"testing"
+
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+)
+
+func Test_Bind(t *testing.T) {
+	defer Bind("")
+
+	tests := []struct {
+		name     string
+		bindings string
+		wantErr  error
+		quit     string
+		forward  string
+	}{
+		{"Defaults", "", nil, "Q", "F"},
+		{"Rebound", "quit:X, Forward:>", nil, "X", ">"},
+		{"Unnamed go back to default", "Forward:>", nil, "Q", ">"},
+		{"Swapped", "Quit:F,Forward:Q", nil, "F", "Q"},
+		{"Unknown action", "Jump:J", errs.ErrInvalidKeyBinding, "F", "Q"},
+		{"No key", "Quit:", errs.ErrInvalidKeyBinding, "F", "Q"},
+		{"Duplicate key", "Quit:B", errs.ErrDuplicateKeyBinding, "F", "Q"},
+		{"Fixed key", "Quit:^", errs.ErrDuplicateKeyBinding, "F", "Q"},
+		{"Menu number", "Quit:1", errs.ErrInvalidKeyBinding, "F", "Q"},
+		{"Longer key", "Quit:QUIT", nil, "QUIT", "F"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Bind(tt.bindings); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Bind(%q) = %v, want %v", tt.bindings, err, tt.wantErr)
+			}
+			if Quit.Action() != tt.quit || Forward.Action() != tt.forward {
+				t.Errorf("Bind(%q) Quit = %v, Forward = %v, want %v and %v", tt.bindings, Quit.Action(), Forward.Action(), tt.quit, tt.forward)
+			}
+		})
+	}
+}
+
+func Test_ValidateKeys(t *testing.T) {
+	tests := []struct {
+		bindings string
+		wantErr  error
+	}{
+		{"Quit:X", nil},
+		{"Jump:J", errs.ErrInvalidKeyBinding},
+		{"Quit:F", errs.ErrDuplicateKeyBinding},
+		{"Quit:", errs.ErrInvalidConfig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.bindings, func(t *testing.T) {
+			c := conf.Defaults()
+			c.KeyBindings = tt.bindings
+			err := c.Validate()
+			if (tt.wantErr == nil) != (err == nil) || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -12,6 +12,9 @@ type Action struct {
 	content string
 	len     int
 	isNum   bool
+	args    []*Arg  // The arguments the action accepts
+	handler Handler // Called with the parsed arguments, if set
+	values  *Args   // The parsed arguments, set by Parse
 }
 
 func New(message string) *Action {
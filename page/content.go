@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	symb "github.com/mt1976/crt/strings/symbols"
+	wdth "github.com/mt1976/crt/strings/width"
 )
 
 // cleanContent removes unwanted characters from the rowContent string
@@ -39,11 +40,11 @@ func (p *Page) formatOption(row pageRow) string {
 	miTitle := row.Title
 	//padd out to 70 characters
 	width := p.width - 7
-	pad := width - (len(miTitle) + len(row.DateTime))
+	pad := width - (wdth.Of(miTitle) + wdth.Of(row.DateTime))
 	if pad > 0 {
 		miTitle = miTitle + strings.Repeat(symb.Space.Symbol(), pad)
 	} else {
-		miTitle = miTitle[:width-(len(row.DateTime)+1)] + " | " + row.DateTime
+		miTitle = wdth.Truncate(miTitle, width-(wdth.Of(row.DateTime)+1)) + " | " + row.DateTime
 	}
 
 	miString := fmt.Sprintf(miNumber + ") " + miTitle)
@@ -0,0 +1,40 @@
+package page
+
+import (
+	locl "github.com/mt1976/crt/language/locale"
+	wdth "github.com/mt1976/crt/strings/width"
+	term "github.com/mt1976/crt/terminal"
+)
+
+// rtl returns true if the active locale is read from right to left, in which case the page's
+// layout is mirrored. The text itself is left in reading order for the terminal to display.
+func rtl() bool {
+	return locl.Current().RTL()
+}
+
+// align returns a row of content aligned to the edge of the text area that it is read from, the
+// right edge in a right to left locale.
+func (p *Page) align(row string) string {
+	if !rtl() {
+		return row
+	}
+	return wdth.PadLeft(row, p.width-4)
+}
+
+// messageColumn returns the column a message in the footer starts at, so that it sits against the
+// edge it is read from.
+func (p *Page) messageColumn(msg string) int {
+	if !rtl() {
+		return term.InputColumn
+	}
+	return p.width - term.InputColumn + 2 - wdth.Of(msg)
+}
+
+// sideColumn returns the column for text shown on the opposite side of a row to its message, such
+// as the paging information.
+func (p *Page) sideColumn(msg string) int {
+	if rtl() {
+		return term.InputColumn
+	}
+	return p.width - wdth.Of(msg) - 1
+}
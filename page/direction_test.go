@@ -0,0 +1,49 @@
+package page
+
+import (
+	"strings"
+	"testing"
+
+	lang "github.com/mt1976/crt/language"
+	wdth "github.com/mt1976/crt/strings/width"
+)
+
+func Test_Mirrored(t *testing.T) {
+	defer lang.SetLocale(lang.Locale())
+	p := &Page{width: 40}
+
+	lang.SetLocale("en-GB")
+	if got := p.align("abc"); got != "abc" {
+		t.Errorf("align() = %q, want it unchanged", got)
+	}
+	if got := p.messageColumn("abc"); got != 3 {
+		t.Errorf("messageColumn() = %v, want 3", got)
+	}
+
+	lang.SetLocale("he-IL")
+	if got := p.align("abc"); wdth.Of(got) != 36 || got[len(got)-3:] != "abc" {
+		t.Errorf("align() = %q, want it against the right edge", got)
+	}
+	if got := p.messageColumn("abc"); got != 36 {
+		t.Errorf("messageColumn() = %v, want 36", got)
+	}
+	if got := p.sideColumn("abc"); got != 3 {
+		t.Errorf("sideColumn() = %v, want 3", got)
+	}
+}
+
+func Test_AddFieldValuePair(t *testing.T) {
+	for _, key := range []string{"Name", "名前", "Cafe\u0301"} {
+		t.Run(key, func(t *testing.T) {
+			p := &Page{width: 80, maxContentRows: 10}
+			p.AddFieldValuePair(key, "value")
+			if len(p.pageRows) != 1 {
+				t.Fatalf("AddFieldValuePair() added %v rows, want 1", len(p.pageRows))
+			}
+			row := p.pageRows[0].RowContent
+			if got := wdth.Of(row[:strings.Index(row, " : ")]); got != 25 {
+				t.Errorf("AddFieldValuePair() field name occupies %v columns, want 25", got)
+			}
+		})
+	}
+}
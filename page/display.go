@@ -1,7 +1,10 @@
 package page
 
 import (
+	"fmt"
+
 	ansi "github.com/bit101/go-ansi"
+	styl "github.com/mt1976/crt/styles"
 )
 
 // Replaces/Substitutions for the following gtrm functions:
@@ -11,9 +14,15 @@ import (
 // gtrm.Flush()
 // gtrm.Clear()
 
+// PrintAt prints the content at the given position, in the active theme's body style.
 func PrintAt(content string, column, row int) {
+	PrintRoleAt(styl.Body, content, column, row)
+}
+
+// PrintRoleAt prints the content at the given position, in the active theme's style for the role.
+func PrintRoleAt(role styl.Role, content string, column, row int) {
 	MoveCursor(column, row)
-	ansi.Print(ansi.Green, content)
+	fmt.Print(styl.Render(role, content))
 }
 
 func Flush() {
@@ -34,10 +43,21 @@ func MoveCursor(column, row int) {
 	ansi.MoveTo(column, row)
 }
 
+// SaveCursor remembers the cursor position, so that content can be drawn elsewhere on the screen
+// without disturbing input, such as when a page is refreshed on a timer.
+func SaveCursor() {
+	fmt.Print("\0337")
+}
+
+// RestoreCursor returns the cursor to the position remembered by SaveCursor.
+func RestoreCursor() {
+	fmt.Print("\0338")
+}
+
 func Println(content string) {
-	ansi.Println(ansi.Green, content)
+	fmt.Println(styl.Render(styl.Body, content))
 }
 
 func Print(content string) {
-	ansi.Print(ansi.Green, content)
+	fmt.Print(styl.Render(styl.Body, content))
 }
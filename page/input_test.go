@@ -0,0 +1,47 @@
+package page
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	errs "github.com/mt1976/crt/errors"
+)
+
+func Test_checkLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		minLen  int
+		maxLen  int
+		wantErr error
+		want    string
+	}{
+		{"No limits", "", 0, 0, nil, ""},
+		{"Within limits", "héllo", 2, 5, nil, ""},
+		{"Too short", "h", 2, 5, errs.ErrInputLengthMinimum, "text must be at least 2 characters"},
+		{"Too long", "héllo!", 2, 5, errs.ErrInputLengthMaximum, "text must be at most 5 characters, is 6"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkLength(tt.text, tt.minLen, tt.maxLen)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("checkLength(%q) = %v, want %v", tt.text, err, tt.wantErr)
+			}
+			if err != nil && err.Error() != tt.want {
+				t.Errorf("checkLength(%q) = %q, want %q", tt.text, err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_inputError(t *testing.T) {
+	p := &Page{}
+	if err := p.inputError(errs.ErrInputCancelled.Wrap(io.EOF)); err != nil {
+		t.Errorf("inputError(cancelled) = %v, want nil", err)
+	}
+	err := p.inputError(errs.ErrInputFailure.Wrap(io.ErrUnexpectedEOF))
+	if !errors.Is(err, errs.ErrInputScannerFailure) || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("inputError() = %v, want ErrInputScannerFailure caused by %v", err, io.ErrUnexpectedEOF)
+	}
+}
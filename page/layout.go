@@ -0,0 +1,385 @@
+package page
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	boxr "github.com/mt1976/crt/box"
+	errs "github.com/mt1976/crt/errors"
+	inpt "github.com/mt1976/crt/input"
+	numb "github.com/mt1976/crt/numbers"
+	actn "github.com/mt1976/crt/page/actions"
+	strg "github.com/mt1976/crt/strings"
+	symb "github.com/mt1976/crt/strings/symbols"
+	wdth "github.com/mt1976/crt/strings/width"
+	styl "github.com/mt1976/crt/styles"
+	term "github.com/mt1976/crt/terminal"
+)
+
+// Size is the share of its parent's space given to a panel, either a fixed number of rows or
+// columns, or a ratio of whatever is left once the fixed panels have been placed.
+type Size struct {
+	fixed int
+	ratio int
+}
+
+// Fixed returns a size of exactly n rows or columns.
+func Fixed(n int) Size {
+	return Size{fixed: n}
+}
+
+// Ratio returns a size that shares the remaining space with the other Ratio panels, in proportion to n.
+func Ratio(n int) Size {
+	return Size{ratio: n}
+}
+
+// The ways a panel can divide its space between its children.
+const (
+	leaf    = iota // A panel with content rather than children
+	columns        // Children are placed side by side
+	rows           // Children are stacked one above the other
+)
+
+// Panel is a titled, bordered region of a page's text area. A panel either holds rows of content,
+// which are paged independently of the page and any other panel, or is split between child panels.
+type Panel struct {
+	lock       sync.Mutex
+	title      string
+	size       Size
+	split      int
+	children   []*Panel
+	rows       []string
+	source     func(width, height int) []string
+	activePage int
+	column     int // The position and size of the panel, set when the layout is drawn
+	row        int
+	width      int
+	height     int
+}
+
+// NewPanel returns an empty panel with the given title and size.
+func NewPanel(title string, size Size) *Panel {
+	return &Panel{title: title, size: size}
+}
+
+// NewColumns returns an untitled panel that places the given panels side by side.
+func NewColumns(size Size, panels ...*Panel) *Panel {
+	return &Panel{size: size, split: columns, children: panels}
+}
+
+// NewRows returns an untitled panel that stacks the given panels one above the other.
+func NewRows(size Size, panels ...*Panel) *Panel {
+	return &Panel{size: size, split: rows, children: panels}
+}
+
+// Title returns the title of the panel.
+func (pl *Panel) Title() string {
+	return pl.title
+}
+
+// Find returns the panel with the given title, searching this panel and its children, or nil if
+// there is none.
+func (pl *Panel) Find(title string) *Panel {
+	if pl.title == title {
+		return pl
+	}
+	for _, c := range pl.children {
+		if found := c.Find(title); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Add appends a row of content to the panel.
+func (pl *Panel) Add(rowContent string) {
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+	rowContent = strg.CleanContent(rowContent)
+	if rowContent == symb.Blank.Symbol() {
+		rowContent = ""
+	}
+	pl.rows = append(pl.rows, rowContent)
+}
+
+// SetRows replaces the content of the panel.
+func (pl *Panel) SetRows(rows []string) {
+	pl.lock.Lock()
+	pl.rows = nil
+	pl.activePage = 0
+	pl.lock.Unlock()
+	for _, r := range rows {
+		pl.Add(r)
+	}
+}
+
+// SetSource sets a function that supplies the panel's content each time it is drawn, given the
+// width and height available. The content replaces any rows added to the panel.
+func (pl *Panel) SetSource(source func(width, height int) []string) {
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+	pl.source = source
+}
+
+// Clear removes all the content from the panel.
+func (pl *Panel) Clear() {
+	pl.SetRows(nil)
+}
+
+// Pages returns the number of pages of content in the panel, as last drawn.
+func (pl *Panel) Pages() int {
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+	return pl.pages()
+}
+
+func (pl *Panel) pages() int {
+	visible := pl.height - 2
+	if visible < 1 || len(pl.rows) == 0 {
+		return 1
+	}
+	return (len(pl.rows) + visible - 1) / visible
+}
+
+// ActivePage returns the index of the page of content shown in the panel.
+func (pl *Panel) ActivePage() int {
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+	return pl.activePage
+}
+
+// Forward moves the panel to its next page of content.
+func (pl *Panel) Forward() error {
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+	if pl.activePage >= pl.pages()-1 {
+		return errs.ErrNoMorePages
+	}
+	pl.activePage++
+	return nil
+}
+
+// Back moves the panel to its previous page of content.
+func (pl *Panel) Back() error {
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+	if pl.activePage == 0 {
+		return errs.ErrNoMorePages
+	}
+	pl.activePage--
+	return nil
+}
+
+// arrange sets the position and size of the panel and, in turn, of its children.
+func (pl *Panel) arrange(column, row, width, height int) {
+	pl.lock.Lock()
+	pl.column, pl.row, pl.width, pl.height = column, row, width, height
+	if pl.activePage >= pl.pages() {
+		pl.activePage = pl.pages() - 1
+	}
+	pl.lock.Unlock()
+
+	space := width
+	if pl.split == rows {
+		space = height
+	}
+	sizes := shares(space, pl.children)
+	offset := 0
+	for i, c := range pl.children {
+		if pl.split == rows {
+			c.arrange(column, row+offset, width, sizes[i])
+		} else {
+			c.arrange(column+offset, row, sizes[i], height)
+		}
+		offset += sizes[i]
+	}
+}
+
+// shares divides space between the panels, giving the fixed panels their size first and sharing
+// the rest between the ratio panels. The last ratio panel takes any rounding remainder.
+func shares(space int, panels []*Panel) []int {
+	sizes := make([]int, len(panels))
+	remaining, ratios, last := space, 0, -1
+	for i, c := range panels {
+		if c.size.ratio > 0 {
+			ratios += c.size.ratio
+			last = i
+			continue
+		}
+		sizes[i] = min(c.size.fixed, max(remaining, 0))
+		remaining -= sizes[i]
+	}
+	if ratios == 0 || remaining <= 0 {
+		return sizes
+	}
+	shared := remaining
+	for i, c := range panels {
+		if c.size.ratio == 0 {
+			continue
+		}
+		if i == last {
+			sizes[i] = remaining
+			break
+		}
+		sizes[i] = shared * c.size.ratio / ratios
+		remaining -= sizes[i]
+	}
+	return sizes
+}
+
+// Panels returns the panels that hold content, rather than being split, in the order they are drawn.
+// They are numbered from 1 in this order on the screen, so they can be paged with, for example, 2F.
+func (pl *Panel) Panels() []*Panel {
+	if pl.split == leaf {
+		return []*Panel{pl}
+	}
+	var panels []*Panel
+	for _, c := range pl.children {
+		panels = append(panels, c.Panels()...)
+	}
+	return panels
+}
+
+// draw draws a panel that holds content, with label as its title. The panel's source, if it has one,
+// is called without the panel locked, so it may change the panel.
+func (pl *Panel) draw(box boxr.BoxStyle, label string) {
+	pl.lock.Lock()
+	source, width, height := pl.source, pl.width, pl.height
+	pl.lock.Unlock()
+	if width < 4 || height < 2 {
+		return
+	}
+	inner, visible := width-2, height-2
+	if source != nil {
+		content := source(inner-2, visible)
+		pl.lock.Lock()
+		pl.rows = content
+		pl.lock.Unlock()
+	}
+
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+	if pl.activePage >= pl.pages() {
+		pl.activePage = pl.pages() - 1
+	}
+	top := strings.Repeat(box.Horizontal, inner)
+	if label != "" && inner > 4 {
+		title := wdth.Truncate(label, inner-4)
+		PrintRoleAt(styl.Border, box.StartLeft+box.Horizontal+symb.Space.Symbol(), pl.column, pl.row)
+		PrintRoleAt(styl.Title, title, pl.column+3, pl.row)
+		used := 3 + wdth.Of(title)
+		PrintRoleAt(styl.Border, symb.Space.Symbol()+strings.Repeat(box.Horizontal, pl.width-used-2)+box.StartRight, pl.column+used, pl.row)
+	} else {
+		PrintRoleAt(styl.Border, box.StartLeft+top+box.StartRight, pl.column, pl.row)
+	}
+
+	first := pl.activePage * visible
+	for i := 0; i < visible; i++ {
+		line := ""
+		if first+i < len(pl.rows) {
+			line = pl.rows[first+i]
+		}
+		PrintRoleAt(styl.Border, box.Upright, pl.column, pl.row+1+i)
+		PrintAt(symb.Space.Symbol()+wdth.Fit(line, inner-2)+symb.Space.Symbol(), pl.column+1, pl.row+1+i)
+		PrintRoleAt(styl.Border, box.Upright, pl.column+pl.width-1, pl.row+1+i)
+	}
+
+	bottom := box.EndLeft + top + box.EndRight
+	PrintRoleAt(styl.Border, bottom, pl.column, pl.row+pl.height-1)
+	if pages := pl.pages(); pages > 1 && inner > 8 {
+		paging := fmt.Sprintf(" %v/%v ", pl.activePage+1, pages)
+		PrintRoleAt(styl.Paging, paging, pl.column+pl.width-1-wdth.Of(paging)-1, pl.row+pl.height-1)
+	}
+}
+
+// SetLayout divides the page's text area into panels. The rows added to the page are not shown
+// while it has a layout.
+func (p *Page) SetLayout(root *Panel) {
+	p.layout = root
+}
+
+// Layout returns the panels the page's text area is divided into, or nil if it has none.
+func (p *Page) Layout() *Panel {
+	return p.layout
+}
+
+// Panel returns the panel in the page's layout with the given title, or nil if there is none.
+func (p *Page) Panel(title string) *Panel {
+	if p.layout == nil {
+		return nil
+	}
+	return p.layout.Find(title)
+}
+
+// DrawLayout draws the page's panels over its text area. It can be called at any time to redraw
+// panels whose content has changed.
+func (p *Page) DrawLayout() {
+	if p.layout == nil {
+		return
+	}
+	p.layout.arrange(term.StartColumn+1, p.textAreaStart, p.width-2, p.textAreaEnd-p.textAreaStart+1)
+	panels := p.layout.Panels()
+	for i, pl := range panels {
+		label := pl.title
+		if len(panels) > 1 {
+			label = strings.TrimSpace(fmt.Sprintf("%v %v", i+1, pl.title))
+		}
+		pl.draw(p.viewPort.BoxStyle(), label)
+	}
+}
+
+// panelPaging reads a request to page one panel of a layout: the panel's number with the Forward or
+// Back action before or after it, such as 2F, F2 or F 2.
+func panelPaging(action string, params []string) (panel int, forward bool, ok bool) {
+	if len(params) > 1 {
+		return 0, false, false
+	}
+	text := strings.ToUpper(action + strings.Join(params, ""))
+	for _, a := range []*actn.Action{actn.Forward, actn.Back} {
+		key := strings.ToUpper(a.Action())
+		number := ""
+		switch {
+		case strings.HasPrefix(text, key):
+			number = strings.TrimPrefix(text, key)
+		case strings.HasSuffix(text, key):
+			number = strings.TrimSuffix(text, key)
+		}
+		if number != "" && numb.IsInt(number) {
+			return numb.ToInt(number), a == actn.Forward, true
+		}
+	}
+	return 0, false, false
+}
+
+// pagePanel moves the numbered panel of the page's layout forward or back a page, and redraws it.
+func (p *Page) pagePanel(panel int, forward bool) {
+	panels := p.layout.Panels()
+	if panel < 1 || panel > len(panels) {
+		p.Error(errs.ErrNoSuchPanel.With(panel, len(panels)))
+		return
+	}
+	move := panels[panel-1].Back
+	if forward {
+		move = panels[panel-1].Forward
+	}
+	if err := move(); err != nil {
+		p.Error(err)
+		return
+	}
+	p.RefreshLayout()
+}
+
+// screenLock stops panels being redrawn from more than one goroutine at a time, or while the input
+// is being drawn.
+var screenLock = &inpt.Screen
+
+// RefreshLayout redraws the page's panels without moving the cursor, so it is safe to call while
+// the user is typing, for example from a timer.
+func (p *Page) RefreshLayout() {
+	screenLock.Lock()
+	defer screenLock.Unlock()
+	SaveCursor()
+	p.DrawLayout()
+	RestoreCursor()
+}
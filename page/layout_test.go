@@ -0,0 +1,119 @@
+package page
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	boxr "github.com/mt1976/crt/box"
+)
+
+func Test_shares(t *testing.T) {
+	tests := []struct {
+		name   string
+		space  int
+		panels []*Panel
+		want   []int
+	}{
+		{"Even ratios", 78, []*Panel{NewPanel("a", Ratio(1)), NewPanel("b", Ratio(1))}, []int{39, 39}},
+		{"Uneven ratios", 78, []*Panel{NewPanel("a", Ratio(1)), NewPanel("b", Ratio(2))}, []int{26, 52}},
+		{"Remainder goes to the last ratio", 10, []*Panel{NewPanel("a", Ratio(1)), NewPanel("b", Ratio(1)), NewPanel("c", Ratio(1))}, []int{3, 3, 4}},
+		{"Fixed and ratio", 20, []*Panel{NewPanel("a", Fixed(5)), NewPanel("b", Ratio(1))}, []int{5, 15}},
+		{"Fixed larger than space", 4, []*Panel{NewPanel("a", Fixed(5)), NewPanel("b", Ratio(1))}, []int{4, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shares(tt.space, tt.panels); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("shares() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_PanelPaging(t *testing.T) {
+	hosts := NewPanel("Hosts", Ratio(1))
+	events := NewPanel("Events", Ratio(2))
+	root := NewRows(Ratio(1), NewPanel("Summary", Fixed(3)), NewColumns(Ratio(1), hosts, events))
+	root.arrange(2, 4, 78, 15)
+
+	if hosts.column != 2 || hosts.row != 7 || hosts.width != 26 || hosts.height != 12 {
+		t.Fatalf("hosts placed at %v,%v size %vx%v", hosts.column, hosts.row, hosts.width, hosts.height)
+	}
+	if events.column != 28 || events.width != 52 {
+		t.Fatalf("events placed at column %v width %v", events.column, events.width)
+	}
+	if root.Find("Events") != events {
+		t.Errorf("Find() did not return the events panel")
+	}
+
+	for i := 0; i < 25; i++ {
+		events.Add("event")
+	}
+	if got := events.Pages(); got != 3 {
+		t.Errorf("Pages() = %v, want 3", got)
+	}
+	if err := events.Back(); err == nil {
+		t.Errorf("Back() on the first page did not fail")
+	}
+	events.Forward()
+	events.Forward()
+	if err := events.Forward(); err == nil {
+		t.Errorf("Forward() on the last page did not fail")
+	}
+	if got := events.ActivePage(); got != 2 {
+		t.Errorf("ActivePage() = %v, want 2", got)
+	}
+	if got := hosts.ActivePage(); got != 0 {
+		t.Errorf("hosts ActivePage() = %v, want 0", got)
+	}
+}
+
+func Test_panelPaging(t *testing.T) {
+	tests := []struct {
+		action  string
+		params  []string
+		panel   int
+		forward bool
+		ok      bool
+	}{
+		{"2F", nil, 2, true, true},
+		{"b1", nil, 1, false, true},
+		{"F3", nil, 3, true, true},
+		{"F", []string{"2"}, 2, true, true},
+		{"F", nil, 0, false, false},
+		{"2", nil, 0, false, false},
+		{"FIND", []string{"x"}, 0, false, false},
+		{"F", []string{"1", "2"}, 0, false, false},
+	}
+	for _, tt := range tests {
+		panel, forward, ok := panelPaging(tt.action, tt.params)
+		if panel != tt.panel || forward != tt.forward || ok != tt.ok {
+			t.Errorf("panelPaging(%v, %v) = %v, %v, %v, want %v, %v, %v", tt.action, tt.params, panel, forward, ok, tt.panel, tt.forward, tt.ok)
+		}
+	}
+}
+
+func Test_PanelSource(t *testing.T) {
+	summary, hosts := NewPanel("Summary", Fixed(3)), NewPanel("Hosts", Ratio(1))
+	root := NewRows(Ratio(1), summary, NewColumns(Ratio(1), hosts, NewPanel("Events", Ratio(1))))
+	if got := root.Panels(); len(got) != 3 || got[0] != summary || got[1] != hosts {
+		t.Fatalf("Panels() = %v panels, want Summary, Hosts and Events", len(got))
+	}
+	root.arrange(2, 4, 78, 15)
+
+	// A source that changes its own panel must not deadlock
+	hosts.SetSource(func(width, height int) []string {
+		hosts.Add("added")
+		return []string{"a", "b"}
+	})
+	done := make(chan bool)
+	go func() {
+		hosts.draw(boxr.Default(), "Hosts")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("draw() deadlocked calling a source that adds to its panel")
+	}
+}
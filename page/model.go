@@ -1,6 +1,7 @@
 package page
 
 import (
+	inpt "github.com/mt1976/crt/input"
 	lang "github.com/mt1976/crt/language"
 	actn "github.com/mt1976/crt/page/actions"
 	term "github.com/mt1976/crt/terminal"
@@ -8,32 +9,35 @@ import (
 
 // Page represents a page in a document or a user interface.
 type Page struct {
-	title            string         // The title of the page.
-	pageRows         []pageRow      // The rows of content on the page.
-	noRows           int            // The number of rows on the page.
-	prompt           *lang.Text     // The prompt displayed to the user.
-	showOptions      bool           // The text to be displayed to the user in the case options are possible
-	actions          []*actn.Action // The available actions on the page.
-	actionLen        int            // The maximum length of an action.
-	blockedActions   []string       // The available actions on the page
-	noPages          int            // The total number of pages.
-	ActivePageIndex  int            // The index of the active page.
-	counter          int            // A counter used for tracking.
-	pageRowCounter   int            // A counter used for tracking the page rows.
-	viewPort         *term.ViewPort // The viewPort object used for displaying the page.
-	headerBarTop     int            // The header row top row
-	headerBarContent int            // The header row content row
-	headerBarBotton  int            // The header row bottom row
-	footerBarTop     int            // The row where the input box starts
-	footerBarInput   int            // The row where the input box is
-	footerBarMessage int            // The row where the info box is
-	footerBarBottom  int            // The last row of the page
-	textAreaStart    int            // The row where the text area starts
-	textAreaEnd      int            // The row where the text area ends
-	height           int            // The height of the page
-	width            int            // The width of the page
-	maxContentRows   int            // The maximum number of rows available for content on the page.
-	helpText         []string       // The help text to be displayed to the user
+	title            string           // The title of the page.
+	pageRows         []pageRow        // The rows of content on the page.
+	noRows           int              // The number of rows on the page.
+	prompt           *lang.Text       // The prompt displayed to the user.
+	showOptions      bool             // The text to be displayed to the user in the case options are possible
+	actions          []*actn.Action   // The available actions on the page.
+	actionLen        int              // The maximum length of an action.
+	blockedActions   []string         // The available actions on the page
+	noPages          int              // The total number of pages.
+	ActivePageIndex  int              // The index of the active page.
+	counter          int              // A counter used for tracking.
+	pageRowCounter   int              // A counter used for tracking the page rows.
+	viewPort         *term.ViewPort   // The viewPort object used for displaying the page.
+	headerBarTop     int              // The header row top row
+	headerBarContent int              // The header row content row
+	headerBarBotton  int              // The header row bottom row
+	footerBarTop     int              // The row where the input box starts
+	footerBarInput   int              // The row where the input box is
+	footerBarMessage int              // The row where the info box is
+	footerBarBottom  int              // The last row of the page
+	textAreaStart    int              // The row where the text area starts
+	textAreaEnd      int              // The row where the text area ends
+	height           int              // The height of the page
+	width            int              // The width of the page
+	maxContentRows   int              // The maximum number of rows available for content on the page.
+	helpText         []string         // The help text to be displayed to the user
+	completers       []inpt.Completer // Completers for the arguments typed after an action
+	secretMask       string           // Shown for each character of a secret, nothing is shown if empty
+	layout           *Panel           // The panels the text area is divided into, if any
 }
 
 // pageRow represents a row of content on a page.
@@ -1,7 +1,6 @@
 package page
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"os"
@@ -10,24 +9,42 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	//	disp "github.com/buger/goterm"
 	spew "github.com/davecgh/go-spew/spew"
 	beep "github.com/gen2brain/beeep"
-	boxr "github.com/mt1976/crt/box"
 	conf "github.com/mt1976/crt/config"
 	dttm "github.com/mt1976/crt/datesTimes"
 	errs "github.com/mt1976/crt/errors"
+	inpt "github.com/mt1976/crt/input"
 	lang "github.com/mt1976/crt/language"
 	numb "github.com/mt1976/crt/numbers"
 	actn "github.com/mt1976/crt/page/actions"
 	strg "github.com/mt1976/crt/strings"
 	symb "github.com/mt1976/crt/strings/symbols"
+	wdth "github.com/mt1976/crt/strings/width"
+	wrap "github.com/mt1976/crt/strings/wrap"
+	styl "github.com/mt1976/crt/styles"
 	term "github.com/mt1976/crt/terminal"
 )
 
-var config = conf.Configuration
+// history is the command history shared by every page in the application. It is loaded the first
+// time an action is prompted for.
+var (
+	history     *inpt.History
+	historyOnce sync.Once
+)
+
+// commandHistory returns the command history, loading it if it has not been loaded yet.
+func commandHistory() *inpt.History {
+	historyOnce.Do(func() {
+		history = inpt.NewHistory(inpt.AppName())
+	})
+	return history
+}
 
 const (
 	first = iota
@@ -48,8 +65,8 @@ func (p *Page) ViewPort() term.ViewPort {
 func NewPage(t *term.ViewPort, pageTitle *lang.Text) *Page {
 	title := pageTitle.Text()
 	// truncate title to 25 characters
-	if pageTitle.Len() > config.TitleLength {
-		title = title[:config.TitleLength] + symb.Truncate.Symbol()
+	if maxLen := conf.Current().TitleLength; pageTitle.Len() > maxLen {
+		title = wdth.Truncate(title, maxLen) + symb.Truncate.Symbol()
 	}
 	p := Page{title: title, pageRows: []pageRow{}, noRows: 0, prompt: lang.TxtPagingPrompt, actions: []*actn.Action{}, actionLen: 0, noPages: 0, ActivePageIndex: 0, counter: 0}
 	p.viewPort = t
@@ -77,6 +94,7 @@ func NewPage(t *term.ViewPort, pageTitle *lang.Text) *Page {
 	p.maxContentRows = (t.Height() - 4)     // Remove the number of rows used for the footer
 	p.maxContentRows = p.maxContentRows - 3 // Remove the number of rows used for the header
 	p.blockedActions = []string{}           // No Blocked Actions
+	p.secretMask = "*"
 	p.ResetSetHelp()
 	p.Clear()
 
@@ -84,7 +102,7 @@ func NewPage(t *term.ViewPort, pageTitle *lang.Text) *Page {
 }
 
 func (p *Page) SetTitle(title *lang.Text) {
-	p.title = p.viewPort.Styles.Bold(title.Text())
+	p.title = title.Text()
 }
 
 // The `Add` function is used to add a new row of data to a page. It takes four parameters:
@@ -113,9 +131,8 @@ func (p *Page) Add(rowContent string, altID string, dateTime string) {
 
 	remainder := ""
 	width := p.width - 5
-	if len(rowContent) > width {
-		remainder = rowContent[width:]
-		rowContent = rowContent[:width]
+	if wdth.Of(rowContent) > width {
+		rowContent, remainder = wdth.Split(rowContent, width)
 	}
 
 	p.pageRowCounter++
@@ -135,7 +152,7 @@ func (p *Page) Add(rowContent string, altID string, dateTime string) {
 func (p *Page) AddAction(validAction *actn.Action) {
 
 	if validAction.Equals("?") {
-		p.Error(errs.ErrInvalidAction, validAction.Action())
+		p.Error(errs.ErrInvalidAction.With(validAction.Action()))
 		return
 	}
 
@@ -156,6 +173,15 @@ func (p *Page) AddAction(validAction *actn.Action) {
 	}
 }
 
+// resetActionLen works out the length of the longest action on the page again, after the keys that
+// choose its actions have been changed.
+func (p *Page) resetActionLen() {
+	p.actionLen = 0
+	for _, action := range p.actions {
+		p.actionLen = max(p.actionLen, action.Len())
+	}
+}
+
 // AddIntAction adds an action to the page with the given integer value
 func (p *Page) AddIntAction(num int) {
 	p.AddAction(actn.New(fmt.Sprintf("%v", num)))
@@ -213,9 +239,7 @@ func (p *Page) AddMenuOption(id int, rowContent string, altID string, dateTime s
 	}
 
 	visible := p.width - 10
-	if len(rowContent) > visible {
-		rowContent = rowContent[:visible]
-	}
+	rowContent = wdth.Truncate(rowContent, visible)
 
 	p.pageRowCounter++
 	mi := pageRow{}
@@ -235,9 +259,14 @@ func (p *Page) formatNumberedOptionText(row pageRow) string {
 	if len(si) < 4 {
 		si = si + strings.Repeat(symb.Space.Symbol(), 4-len(si))
 	}
-	seq := bold(si)
+	seq := styl.Render(styl.Highlight, si)
 
 	miString := fmt.Sprintf("%v) %v", seq, row.Title)
+	if rtl() {
+		// Mirrored, with the number against the right edge
+		seq = styl.Render(styl.Highlight, wdth.PadLeft(strconv.Itoa(row.ID), 4))
+		miString = p.align(fmt.Sprintf("%v (%v", row.Title, seq))
+	}
 	return miString
 }
 
@@ -252,15 +281,19 @@ func (p *Page) AddFieldValuePair(key any, value string) {
 
 	keyString, err := translate(key)
 	if err != nil {
-		p.Error(err, keyString)
+		p.Error(err)
 		return
 	}
 
-	// format the field value pair
-	format := "%-25s : %s"
+	// format the field value pair, padding the field name by the columns it occupies
 	keyString = bold(keyString)
+	if rtl() {
+		// Mirrored, with the field name against the right edge
+		p.Add(p.align(value+" : "+wdth.PadLeft(keyString, 25)), "", "")
+		return
+	}
 	//+ Printewline
-	p.Add(fmt.Sprintf(format, keyString, value), "", "")
+	p.Add(wdth.PadRight(keyString, 25)+" : "+value, "", "")
 }
 
 func translate(key any) (string, error) {
@@ -273,9 +306,7 @@ func translate(key any) (string, error) {
 		keyText := key.(lang.Text)
 		keyString = keyText.Text()
 	default:
-		errTxt := fmt.Sprintf("invalid object type [%v]", reflect.TypeOf(t).String())
-		err := errors.New(errTxt)
-		return "", err
+		return "", errs.ErrInvalidFieldName.With(reflect.TypeOf(t).String())
 	}
 	return keyString, nil
 }
@@ -299,13 +330,13 @@ func (p *Page) addColumns(isBold bool, columns ...string) {
 
 	// Check if the colsize will be wide enough
 	if colSize < 5 {
-		p.Error(errs.ErrAddColumns, strconv.Itoa(noColumns))
+		p.Error(errs.ErrAddColumns.With(noColumns, maxCols))
 		os.Exit(1)
 	}
 
 	// Check the number of columns
 	if noColumns > maxCols {
-		p.Error(errs.ErrAddColumns, strconv.Itoa(len(columns)), strconv.Itoa(maxCols))
+		p.Error(errs.ErrAddColumns.With(len(columns), maxCols))
 		os.Exit(1)
 	}
 
@@ -315,19 +346,8 @@ func (p *Page) addColumns(isBold bool, columns ...string) {
 		// Get the current column
 		op := columns[i]
 
-		// Check if the column is longer than the column width
-		if len(op) > colSize {
-			// Truncate the column to the column width
-			op = op[0 : colSize-1]
-		} else {
-			// Calculate the number of spaces to add
-			noToAdd := colSize - (len(op) + 1)
-
-			// Add the spaces to the column
-			if noToAdd > 0 {
-				op = op + strings.Repeat(symb.Space.Symbol(), noToAdd)
-			}
-		}
+		// Truncate or pad the column to the column width, leaving room for the separator
+		op = wdth.Fit(op, colSize-1)
 
 		// Add the column to the output slice
 		output = append(output, op)
@@ -384,10 +404,16 @@ func (p *Page) AddBlankRow() {
 	p.Add(symb.Blank.Symbol(), "", "")
 }
 
+// AddParagraph adds the lines of a paragraph to the page, wrapping them on word boundaries to fit
+// the width of the page.
 func (p *Page) AddParagraph(msg []string) {
-	// make sure the lines are no longer than the screen width and wrap them if they are.
-	for _, s := range msg {
-		s = strg.TrimRepeatingCharacters(s, symb.Space.Symbol())
+	p.AddParagraphAligned(msg, wrap.Left)
+}
+
+// AddParagraphAligned adds the lines of a paragraph to the page, wrapped to fit the width of the page
+// and aligned left, right, centred or fully justified.
+func (p *Page) AddParagraphAligned(msg []string, align wrap.Alignment) {
+	for _, s := range wrap.Paragraph(msg, wrap.Options{Width: p.width - 5, Align: align}) {
 		p.Add(s, "", "")
 	}
 }
@@ -423,7 +449,7 @@ func (p *Page) Display_Actions() (nextAction *actn.Action) {
 			// }
 			return &nextAction
 		default:
-			p.Error(errs.ErrInvalidAction, nextAction.Action())
+			p.Error(errs.ErrInvalidAction.With(nextAction.Action()))
 		}
 	}
 	return &actn.Action{}
@@ -436,9 +462,11 @@ func (p *Page) Clear() {
 	p.Footer()
 }
 
+// Display_Input prompts for a line of text and returns the whole line, including any spaces within it.
+// The text must be between minLen and maxLen characters, a limit of 0 is not checked.
 func (p *Page) Display_Input(minLen, maxLen int) (nextAction string, selected pageRow) {
 	if p.prompt.Text() == "" {
-		p.Error(errs.ErrNoPromptSpecified, lang.SetPrompt.Text())
+		p.Error(errs.ErrNoPromptSpecified.With(lang.SetPrompt.Text()))
 		os.Exit(1)
 	}
 	if minLen > 0 || maxLen > 0 {
@@ -453,34 +481,85 @@ func (p *Page) Display_Input(minLen, maxLen int) (nextAction string, selected pa
 
 		p.PagingInfo(p.ActivePageIndex+1, p.noPages+1)
 
-		out := p.Input(p.prompt, "")
-		if actn.IsActionIn(out, actn.Quit) {
+		out := p.inputLine(p.prompt)
+		if actn.Quit.Equals(out) {
 			return actn.Quit.Action(), pageRow{}
 		}
 
-		if actn.IsActionIn(out, actn.Exit) {
+		if actn.Exit.Equals(out) {
 			os.Exit(0)
 		}
 
-		if actn.IsActionIn(out, actn.Help) {
+		if actn.Help.Equals(out) {
 			p.Help()
+			continue
 		}
 
-		if minLen > 0 && len(out) < minLen {
-			p.Error(errs.ErrInputLengthMinimum, out, strconv.Itoa(minLen))
+		if err := checkLength(out, minLen, maxLen); err != nil {
+			p.Error(err)
+			continue
 		}
 
-		if maxLen > 0 && len(out) > maxLen {
-			p.Error(errs.ErrInputLengthMaximum, out, strconv.Itoa(maxLen), strconv.Itoa(len(out)))
+		return out, pageRow{}
+	}
+}
+
+// Display_TextArea lets the user edit several lines of text in the content area of the page, starting
+// with the given lines. The text must be between minLen and maxLen characters, a limit of 0 is not
+// checked. Ctrl-S saves the text and Escape cancels, returning ErrInputCancelled.
+func (p *Page) Display_TextArea(text []string, minLen, maxLen int) ([]string, error) {
+	hint := lang.HelpHint.Text() + symb.Space.Symbol() + lang.TextAreaHint.Text()
+	if minLen > 0 || maxLen > 0 {
+		hint = hint + symb.TextDelimiter.Symbol() + p.minMaxHint(minLen, maxLen)
+	}
+
+	Clear()
+	p.Header(p.title)
+	p.Body()
+	p.Footer()
+
+	area := inpt.NewTextArea(term.InputColumn, p.textAreaStart, p.width-4, p.textAreaEnd-p.textAreaStart+1)
+	area.SetText(text)
+	area.Events = reloads
+	for {
+		p.ClearContent(p.footerBarMessage)
+		PrintAt(hint, p.messageColumn(hint), p.footerBarMessage)
+
+		lines, err := area.Edit()
+		if errors.Is(err, errs.ErrInputCancelled) {
+			return nil, err
+		}
+		if err = p.inputError(err); err != nil {
+			return nil, err
 		}
 
-		if len(out) >= minLen && len(out) <= maxLen {
-			return out, pageRow{}
+		joined := strings.Join(lines, symb.Newline.Symbol())
+		if err := checkLength(joined, minLen, maxLen); err != nil {
+			p.Error(err)
+			continue
 		}
+		return lines, nil
+	}
+}
+
+// checkLength returns an error if the text is shorter than minLen or longer than maxLen characters.
+// A limit of 0 is not checked.
+func checkLength(text string, minLen, maxLen int) error {
+	length := utf8.RuneCountInString(text)
+	if minLen > 0 && length < minLen {
+		return errs.ErrInputLengthMinimum.With(minLen)
+	}
+	if maxLen > 0 && length > maxLen {
+		return errs.ErrInputLengthMaximum.With(maxLen, length)
 	}
+	return nil
 }
 
 func drawScreen(p *Page) {
+	setShown(p)
+	if c := takeReload(); c != nil {
+		applyReload(p, *c)
+	}
 
 	rowsDisplayed := 0
 
@@ -488,6 +567,13 @@ func drawScreen(p *Page) {
 	p.Header(p.title)
 	p.Body()
 
+	if p.layout != nil {
+		p.DrawLayout()
+		p.Footer()
+		p.PagingInfo(p.ActivePageIndex+1, p.noPages+1)
+		return
+	}
+
 	for i := range p.pageRows {
 		if p.ActivePageIndex == p.pageRows[i].PageIndex {
 			rowsDisplayed++
@@ -509,10 +595,12 @@ func (p *Page) Header(msg string) {
 	PrintAt(p.boxPartDraw(first), term.StartColumn, p.headerBarTop)
 	width := p.width
 	PrintAt(p.boxPartDraw(99), term.StartColumn, p.headerBarContent)
-	PrintAt(lang.ApplicationName.Text(), term.InputColumn, p.headerBarContent)
-	midway := (width - len(msg)) / 2
-	PrintAt(msg, midway, p.headerBarContent)
-	PrintAt(dttm.DateTimeString(), width-(len(dttm.DateTimeString())+1), p.headerBarContent)
+	midway := wdth.Offset(msg, width)
+	PrintRoleAt(styl.Title, msg, midway, p.headerBarContent)
+	// The application name is on the side the header is read from, and the date and time opposite
+	name, now := lang.ApplicationName.Text(), dttm.DateTimeString()
+	PrintAt(name, p.messageColumn(name), p.headerBarContent)
+	PrintAt(now, p.sideColumn(now), p.headerBarContent)
 	PrintAt(p.boxPartDraw(middle), term.StartColumn, p.headerBarBotton)
 }
 func (p *Page) Body() {
@@ -524,7 +612,7 @@ func (p *Page) Body() {
 func (p *Page) Footer() {
 	PrintAt(p.boxPartDraw(middle), term.StartColumn, p.footerBarTop)
 	PrintAt(p.boxPartDraw(99), term.StartColumn, p.footerBarInput)
-	PrintAt(p.FormatRowOutput(p.prompt.Text()), term.StartColumn, p.footerBarMessage)
+	PrintAt(p.FormatRowOutput(p.align(p.prompt.Text())), term.StartColumn, p.footerBarMessage)
 	PrintAt(p.boxPartDraw(last), term.StartColumn, p.footerBarBottom)
 }
 
@@ -533,13 +621,19 @@ func (p *Page) displayIt() (actn.Action, pageRow) {
 
 	drawScreen(p)
 
+	var chosen *actn.Action
 	inputAction := ""
-	ok := false
-	for !ok {
-		inputAction = p.Input(p.prompt, "")
+	for chosen == nil {
+		var params []string
+		inputAction, params = actn.SplitCommand(p.actionLine(p.prompt))
+
+		if panel, forward, ok := panelPaging(inputAction, params); ok && p.layout != nil {
+			p.pagePanel(panel, forward)
+			continue
+		}
 
 		if len(inputAction) > p.actionLen {
-			p.Error(errs.ErrInvalidActionLen, inputAction, strconv.Itoa(len(inputAction)), strconv.Itoa(p.actionLen))
+			p.Error(errs.ErrInvalidActionLen.With(inputAction, len(inputAction), p.actionLen))
 			continue
 		}
 
@@ -548,63 +642,279 @@ func (p *Page) displayIt() (actn.Action, pageRow) {
 			continue
 		}
 
-		ok = actn.IsActionIn(strg.Upcase(inputAction), p.actions...)
-		if !ok {
-			p.Error(errs.ErrInvalidAction, inputAction)
+		match := p.findAction(inputAction)
+		if match == nil {
+			p.Error(errs.ErrInvalidAction.With(inputAction))
+			continue
+		}
+
+		// Parse and validate any arguments given with the action
+		parsed, err := match.Parse(params)
+		if err != nil {
+			p.Error(err)
+			continue
+		}
+
+		// Hand the parsed arguments to the action's handler, if it has one
+		if handler := parsed.Handler(); handler != nil {
+			if err := handler(parsed.Args()); err != nil {
+				p.Error(err)
+				continue
+			}
 		}
+		chosen = parsed
 	}
 	// if nextAction is a numnber, find the menu item
 	if numb.IsInt(inputAction) {
 		pos, _ := strconv.Atoi(inputAction)
-		rtnAction := actn.New(inputAction)
-		return *rtnAction, p.pageRows[pos-1]
+		return *chosen, p.pageRows[pos-1]
 	}
 
 	if actn.Exit.Equals(inputAction) {
 		os.Exit(0)
 	}
-	return *actn.New(inputAction), pageRow{}
+	return *chosen, pageRow{}
+}
+
+// findAction returns the action on the page matching the given name, or nil if there is none.
+func (p *Page) findAction(name string) *actn.Action {
+	for _, action := range p.actions {
+		if action.Equals(name) {
+			return action
+		}
+	}
+	return nil
 }
 
 // The `Input` function is a method of the `Crt` struct. It is used to display a prompt for the user for input on the
 // terminal.
 func (p *Page) Input(msg *lang.Text, options string) string {
-	mesg := msg.Text() + symb.PromptSymbol.Symbol() + symb.Space.Symbol()
-	if p.showOptions {
-		mesg = msg.Text() + symb.Space.Symbol() + strg.Italic(p.GetOptions(true))
-		p.showOptions = false
+	p.showPrompt(msg)
+
+	input, err := p.getUserInput()
+	if err != nil {
+		p.Error(errs.ErrInputFailure.Wrap(err))
 	}
 
-	PrintAt(mesg, term.InputColumn, p.footerBarMessage)
-	p.PagingInfo(p.ActivePageIndex, p.noPages)
+	return input
+}
 
-	input, err := p.getUserInput()
+// inputLine displays the prompt and returns the whole line entered by the user. The line is not
+// added to the command history.
+func (p *Page) inputLine(msg *lang.Text) string {
+	p.showPrompt(msg)
+
+	input, err := p.getUserLine(nil)
+	if err != nil {
+		p.Error(errs.ErrInputFailure.Wrap(err))
+	}
+
+	return input
+}
+
+// actionLine displays the prompt and returns the action entered by the user, with its arguments. The
+// line is added to the command history, which can be navigated with up and down.
+func (p *Page) actionLine(msg *lang.Text) string {
+	p.showPrompt(msg)
+
+	input, err := p.getUserLine(commandHistory())
 	if err != nil {
-		p.Error(errs.ErrInputFailure, err.Error())
+		p.Error(errs.ErrInputFailure.Wrap(err))
 	}
 
 	return input
 }
 
+func (p *Page) showPrompt(msg *lang.Text) {
+	mesg := msg.Text() + symb.PromptSymbol.Symbol() + symb.Space.Symbol()
+	if p.showOptions {
+		mesg = msg.Text() + symb.Space.Symbol() + strg.Italic(p.GetOptions(true))
+		p.showOptions = false
+	}
+
+	PrintRoleAt(styl.Prompt, mesg, p.messageColumn(mesg), p.footerBarMessage)
+	p.PagingInfo(p.ActivePageIndex, p.noPages)
+}
+
 func (p *Page) ShowOptions() {
 	p.showOptions = true
 }
 
 func (p *Page) getUserInput() (string, error) {
-	MoveCursor(term.InputColumn, p.footerBarInput)
-	scanner := bufio.NewScanner(os.Stdin)
-	if !scanner.Scan() {
-		return "", errs.ErrInputScannerFailure
+	line, err := p.getUserLine(nil)
+	if err != nil {
+		return "", err
 	}
 	var input string
-	fmt.Sscanf(scanner.Text(), "%s", &input)
+	fmt.Sscanf(line, "%s", &input)
 	return input, nil
 }
 
+// getUserLine reads a whole line of input, with surrounding whitespace removed. If hist is not nil
+// it can be navigated and the line is added to it.
+func (p *Page) getUserLine(hist *inpt.History) (string, error) {
+	MoveCursor(term.InputColumn, p.footerBarInput)
+	editor := inpt.NewLineEditor(term.InputColumn, p.footerBarInput, p.width-4)
+	editor.History = hist
+	editor.Commands = inpt.Combine(inpt.CompleterFunc(p.completeAction), inpt.CompleterFunc(p.completeMenuOption))
+	editor.Args = inpt.Combine(p.completers...)
+	editor.ArgsFor = p.completeArg
+	editor.List = p.listCandidates
+	editor.Events = reloads
+	line, err := editor.ReadLine()
+	if err = p.inputError(err); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// inputError converts an error from the line editor into the error returned to the caller. Escape
+// cancels the input, which is returned as an empty line. Any other failure is returned as
+// ErrInputScannerFailure, caused by the editor's error.
+func (p *Page) inputError(err error) error {
+	switch {
+	case err == nil, errors.Is(err, errs.ErrInputCancelled):
+		return nil
+	case errors.Is(err, errs.ErrInputInterrupted):
+		// Ctrl-C no longer raises an interrupt while the terminal is in raw mode, so honour it here
+		Clear()
+		os.Exit(1)
+	}
+	return errs.ErrInputScannerFailure.Wrap(err)
+}
+
+// SetSecretMask sets the string shown for each character of a secret. If mask is empty nothing is
+// shown while the secret is typed.
+func (p *Page) SetSecretMask(mask string) {
+	p.secretMask = mask
+}
+
+// Display_Secret prompts for a secret, such as a password or API token, without echoing it. The
+// secret must be between minLen and maxLen characters, a limit of 0 is not checked. If confirm is
+// true the secret must be entered twice. Escape cancels the input, returning ErrInputCancelled.
+//
+// The secret is never added to the command history and is not held by the page, so it cannot appear
+// in the output of Dump.
+func (p *Page) Display_Secret(minLen, maxLen int, confirm bool) (string, error) {
+	if p.prompt.Text() == "" {
+		p.Error(errs.ErrNoPromptSpecified.With(lang.SetPrompt.Text()))
+		os.Exit(1)
+	}
+	if minLen > 0 || maxLen > 0 {
+		p.Add(symb.Blank.Symbol(), "", "")
+		p.Add(lang.HelpHint.Text(), "", "")
+		p.Add(p.minMaxHint(minLen, maxLen), "", "")
+	}
+	drawScreen(p)
+
+	for {
+		secret, err := p.readSecret(p.prompt)
+		if err != nil {
+			return "", err
+		}
+
+		if err := checkLength(secret, minLen, maxLen); err != nil {
+			p.Error(err)
+			continue
+		}
+		if !confirm {
+			return secret, nil
+		}
+
+		again, err := p.readSecret(lang.SecretConfirmPrompt)
+		if err != nil {
+			return "", err
+		}
+		if again != secret {
+			p.Error(errs.ErrSecretMismatch)
+			continue
+		}
+		return secret, nil
+	}
+}
+
+// readSecret displays the prompt and reads a secret, masking or hiding what is typed.
+func (p *Page) readSecret(msg *lang.Text) (string, error) {
+	p.showPrompt(msg)
+	MoveCursor(term.InputColumn, p.footerBarInput)
+	editor := inpt.NewSecretEditor(term.InputColumn, p.footerBarInput, p.width-4, p.secretMask)
+	editor.Events = reloads
+	secret, err := editor.ReadLine()
+	p.ClearContent(p.footerBarInput)
+	if errors.Is(err, errs.ErrInputCancelled) {
+		return "", err
+	}
+	if err = p.inputError(err); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// AddCompleter adds a completer offering candidates for the arguments typed after an action, such as
+// file paths or host names.
+func (p *Page) AddCompleter(completer inpt.Completer) {
+	p.completers = append(p.completers, completer)
+}
+
+// completeAction returns the actions on the page that start with the given word.
+func (p *Page) completeAction(word string) []inpt.Candidate {
+	var matches []inpt.Candidate
+	for _, action := range p.actions {
+		if p.IsBlockedAction(action.Action()) || !inpt.HasPrefixFold(action.Action(), word) {
+			continue
+		}
+		matches = append(matches, inpt.Candidate{Value: action.Action(), Display: action.Usage()})
+	}
+	return matches
+}
+
+// completeArg returns the completer for the argument at index typed after the named action, or nil
+// to use the page's completers.
+func (p *Page) completeArg(name string, index int) inpt.Completer {
+	if action := p.findAction(name); action != nil {
+		return action.ArgCompleter(index)
+	}
+	return nil
+}
+
+// completeMenuOption returns the menu options whose title contains a word starting with the given
+// word. The option is completed to its number, which is the action that chooses it.
+func (p *Page) completeMenuOption(word string) []inpt.Candidate {
+	var matches []inpt.Candidate
+	if word == "" {
+		return matches
+	}
+	for _, row := range p.pageRows {
+		if row.ID == 0 || row.Title == "" {
+			continue
+		}
+		for _, titleWord := range strings.Fields(row.Title) {
+			if inpt.HasPrefixFold(titleWord, word) {
+				display := strconv.Itoa(row.ID) + ") " + strings.TrimSpace(row.Title)
+				matches = append(matches, inpt.Candidate{Value: strconv.Itoa(row.ID), Display: display})
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// listCandidates shows the candidates of an ambiguous completion in the message row.
+func (p *Page) listCandidates(candidates []inpt.Candidate) {
+	var list []string
+	for _, c := range candidates {
+		list = append(list, c.Display)
+	}
+	msg := strings.Join(list, symb.Space.Symbol()+symb.Space.Symbol())
+	msg = wdth.TruncateWith(msg, p.width-4, symb.Truncate.Symbol())
+	p.ClearContent(p.footerBarMessage)
+	PrintAt(msg, p.messageColumn(msg), p.footerBarMessage)
+}
+
 func (p *Page) Dump(in ...string) {
 
 	// Only proceed if page dumping is active in the config file
-	if !config.PageDumpActive {
+	if !conf.Current().PageDumpActive {
 		return
 	}
 	// OK Proceed - Sleep for a second to stop dumping multiple files with same timestamp
@@ -617,9 +927,9 @@ func (p *Page) Dump(in ...string) {
 	thisPath, _ := os.Getwd()
 	currentpath := filepath.Join(thisPath, filename)
 
-	if config.PageDumpPath != "" {
+	if dumpPath := conf.Current().PageDumpPath; dumpPath != "" {
 		//thisPath = thisPath + config.PageDumpPath
-		currentpath = filepath.Join(thisPath, config.PageDumpPath, filename)
+		currentpath = filepath.Join(thisPath, dumpPath, filename)
 	}
 
 	f, err := os.Create(currentpath)
@@ -644,27 +954,31 @@ func (p *Page) Dump(in ...string) {
 
 func (p *Page) FormatRowOutput(msg string) string {
 	p.viewPort.DelayIt()
-	xx := fmt.Sprintf("%s %s", boxr.Upright, msg)
+	upright := styl.Render(styl.Border, p.viewPort.BoxStyle().Upright)
+	xx := fmt.Sprintf("%s %s", upright, styl.Render(styl.Body, msg))
 	// place a upright at the end of the string at the last position based on screen width
-	if len(xx) < p.width {
-		addChars := (p.width - len(xx)) + 1
-		xx = xx + strings.Repeat(" ", addChars) + boxr.Upright
+	if wdth.Of(xx) < p.width {
+		addChars := (p.width - wdth.Of(xx)) + 1
+		xx = xx + strings.Repeat(" ", addChars) + upright
 	}
 	return xx
 }
 
+// boxPartDraw returns a row of the page frame, in the active theme's border style.
 func (p *Page) boxPartDraw(which int) string {
-	bar := strings.Repeat(boxr.Horizontal, p.width-2)
+	box := p.viewPort.BoxStyle()
+	bar := strings.Repeat(box.Horizontal, p.width-2)
 	space := strings.Repeat(symb.Space.Symbol(), p.width-2)
+	border := func(s string) string { return styl.Render(styl.Border, s) }
 	switch which {
 	case first:
-		return boxr.StartLeft + bar + boxr.StartRight
+		return border(box.StartLeft + bar + box.StartRight)
 	case last:
-		return boxr.EndLeft + bar + boxr.EndRight
+		return border(box.EndLeft + bar + box.EndRight)
 	case middle, lineBreak:
-		return boxr.DividerLeft + bar + boxr.DividerRight
+		return border(box.DividerLeft + bar + box.DividerRight)
 	default:
-		return boxr.Upright + space + boxr.Upright
+		return border(box.Upright) + space + border(box.Upright)
 	}
 }
 
@@ -679,25 +993,24 @@ func (p *Page) AddBreakRow() {
 }
 
 func (p *Page) PagingInfo(page, ofPages int) {
-	msg := fmt.Sprintf(lang.Paging.Text(), page+1, ofPages+1)
-	lmsg := len(msg)
+	msg := lang.Paging.Format(page+1, ofPages+1)
+	lmsg := wdth.Of(msg)
 	if ofPages == 0 {
 		msg = strings.Repeat(" ", lmsg)
 	}
-	msg = p.viewPort.Styles.Yellow(msg)
-	PrintAt(msg, p.width-lmsg-1, p.footerBarMessage)
+	PrintRoleAt(styl.Paging, msg, p.sideColumn(msg), p.footerBarMessage)
 }
 
 func (p *Page) InputHintInfo(msg *lang.Text) {
 	//lmsg := msg.Len()
-	PrintAt(msg.Text(), p.width-msg.Len()-1, p.footerBarMessage)
+	PrintAt(msg.Text(), p.sideColumn(msg.Text()), p.footerBarMessage)
 }
 
 func (p *Page) minMaxHint(min, max int) string {
 	if min <= 0 && max <= 0 {
 		return ""
 	}
-	msg := fmt.Sprintf(lang.MinMax.Text(), min, max)
+	msg := lang.MinMax.Format(min, max)
 	return msg
 }
 
@@ -748,69 +1061,132 @@ func (p *Page) ResetPrompt() {
 	p.prompt = lang.TxtPagingPrompt
 }
 
+// Error shows err in the message bar, labelled and coloured by its severity, for DefaultErrorDelay
+// seconds. Warnings and worse also beep.
 func (p *Page) Error(err error, msg ...string) {
 	p.ClearContent(p.footerBarMessage)
-	pp := p.formatMessage(err.Error(), p.viewPort.Styles.Red(lang.Warning.Text()), msg...)
-	PrintAt(pp, term.InputColumn, p.footerBarMessage)
-	beep.Beep(config.DefaultBeepFrequency, config.DefaultBeepDuration)
+	severity := errs.SeverityOf(err)
+	role, label := severityStyle(severity)
+	pp := p.formatMessage(errs.Format(err, values(msg)...), styl.Render(role, label.Text()))
+	PrintAt(pp, p.messageColumn(pp), p.footerBarMessage)
+	if severity >= errs.Warning {
+		beep.Beep(conf.Current().DefaultBeepFrequency, conf.Current().DefaultBeepDuration)
+	}
 	oldDelay := p.viewPort.Delay()
-	p.viewPort.SetDelayInSec(config.DefaultErrorDelay)
+	p.viewPort.SetDelayInSec(conf.Current().DefaultErrorDelay)
 	p.viewPort.DelayIt()
 	p.viewPort.SetDelayInMs(oldDelay)
 	p.ClearContent(p.footerBarInput)
 	p.ClearContent(p.footerBarMessage)
 }
 
+// severityStyle returns the role and label an error of the given severity is shown with.
+func severityStyle(severity errs.Severity) (styl.Role, *lang.Text) {
+	switch severity {
+	case errs.Info:
+		return styl.Info, lang.Info
+	case errs.Warning:
+		return styl.Warning, lang.Warning
+	case errs.Fatal:
+		return styl.Error, lang.Fatal
+	}
+	return styl.Error, lang.Error
+}
+
 func (p *Page) Info(info *lang.Text, msg ...string) {
 	p.ClearContent(p.footerBarMessage)
 	p.PagingInfo(p.ActivePageIndex, p.noPages)
-	pp := p.formatMessage(info.Text(), p.viewPort.Styles.White(lang.Info.Text()), msg...)
-	PrintAt(pp, term.InputColumn, p.footerBarMessage)
+	pp := p.formatMessage(info.Format(values(msg)...), styl.Render(styl.Info, lang.Info.Text()))
+	PrintAt(pp, p.messageColumn(pp), p.footerBarMessage)
 }
 
 func (p *Page) Hint(info *lang.Text, msg ...string) {
 	p.ClearContent(p.footerBarMessage)
 	p.PagingInfo(p.ActivePageIndex, p.noPages)
-	pp := p.formatMessage(info.Text(), p.viewPort.Styles.Cyan(lang.Hint.Text()), msg...)
-	PrintAt(pp, term.InputColumn, p.footerBarMessage)
+	pp := p.formatMessage(info.Format(values(msg)...), styl.Render(styl.Hint, lang.Hint.Text()))
+	PrintAt(pp, p.messageColumn(pp), p.footerBarMessage)
 }
 
 func (p *Page) Warning(warning lang.Text, msg ...string) {
 	p.ClearContent(p.footerBarMessage)
-	pp := p.formatMessage(warning.Text(), p.viewPort.Styles.Yellow(lang.Warning.Text()), msg...)
-	PrintAt(pp, term.InputColumn, p.footerBarMessage)
-	beep.Beep(config.DefaultBeepFrequency, config.DefaultBeepDuration)
+	pp := p.formatMessage(warning.Format(values(msg)...), styl.Render(styl.Warning, lang.Warning.Text()))
+	PrintAt(pp, p.messageColumn(pp), p.footerBarMessage)
+	beep.Beep(conf.Current().DefaultBeepFrequency, conf.Current().DefaultBeepDuration)
 	oldDelay := p.viewPort.Delay()
-	p.viewPort.SetDelayInSec(config.DefaultErrorDelay)
+	p.viewPort.SetDelayInSec(conf.Current().DefaultErrorDelay)
 	p.viewPort.DelayIt()
 	p.viewPort.SetDelayInMs(oldDelay)
 	p.ClearContent(p.footerBarInput)
 	p.ClearContent(p.footerBarMessage)
 }
+
+// ShowStatus shows a message in the footer's top border, above the input row, such as the progress
+// of work running in the background. The prompt, paging information and input are left as they are,
+// as is the cursor. An empty message clears the status.
+func (p *Page) ShowStatus(msg string) {
+	screenLock.Lock()
+	defer screenLock.Unlock()
+	SaveCursor()
+	PrintAt(p.boxPartDraw(middle), term.StartColumn, p.footerBarTop)
+	if text := strings.TrimRight(wdth.Truncate(msg, p.StatusWidth()), symb.Space.Symbol()); text != "" {
+		text = symb.Space.Symbol() + text + symb.Space.Symbol()
+		column := term.InputColumn
+		if rtl() {
+			column = term.InputColumn + p.StatusWidth() + 2 - wdth.Of(text)
+		}
+		PrintAt(text, column, p.footerBarTop)
+	}
+	RestoreCursor()
+}
+
+// ShowHeaderStatus shows a short message in the header, between the application name and the title,
+// leaving the cursor where it was. The message is cut short if there is not enough room.
+func (p *Page) ShowHeaderStatus(msg string) {
+	start := term.InputColumn + wdth.Of(lang.ApplicationName.Text()) + 2
+	room := wdth.Offset(p.title, p.width) - 2 - start
+	if rtl() {
+		// Mirrored, between the title and the application name on the right
+		start = wdth.Offset(p.title, p.width) + wdth.Of(p.title) + 2
+		room = p.messageColumn(lang.ApplicationName.Text()) - 2 - start
+	}
+	if room <= 0 {
+		return
+	}
+	screenLock.Lock()
+	defer screenLock.Unlock()
+	SaveCursor()
+	PrintAt(wdth.Fit(msg, room), start, p.headerBarContent)
+	RestoreCursor()
+}
+
+// StatusWidth returns the number of columns available to ShowStatus.
+func (p *Page) StatusWidth() int {
+	return p.width - 6
+}
+
 func (p *Page) Success(message *lang.Text, msg ...string) {
 	p.ClearContent(p.footerBarMessage)
 	p.PagingInfo(p.ActivePageIndex, p.noPages)
-	pp := p.formatMessage(message.Text(), bold(lang.Success.Text()), msg...)
-	PrintAt(pp, term.InputColumn, p.footerBarMessage)
+	pp := p.formatMessage(message.Format(values(msg)...), styl.Render(styl.Success, lang.Success.Text()))
+	PrintAt(pp, p.messageColumn(pp), p.footerBarMessage)
 }
 
-func (p *Page) formatMessage(errText, promptTxt string, msg ...string) string {
-
-	if len(msg) > 0 {
-		// check for enough %v strings in the error
-		// if not enough then add them on the end
-		noVars := strings.Count(errText, "%v")
-		if noVars < len(msg) {
-			errText = errText + strings.Repeat(" %v", len(msg)-noVars)
-		}
+// formatMessage puts the prompt in front of a message, or after it in a right to left locale. The
+// message is shown as it is, its placeholders having already been filled in.
+func (p *Page) formatMessage(text, promptTxt string) string {
+	if rtl() {
+		return text + symb.Space.Symbol() + promptTxt
 	}
+	return promptTxt + text
+}
 
-	qq := errText
+// values returns the strings as values for a message's placeholders.
+func values(msg []string) []any {
+	args := make([]any, len(msg))
 	for i := range msg {
-		qq = strings.Replace(qq, "%v", fmt.Sprintf("%v", msg[i]), 1)
+		args[i] = msg[i]
 	}
-	errText = ("" + promptTxt + "") + qq
-	return errText
+	return args
 }
 
 func (p *Page) Clearline(row int) {
@@ -892,7 +1268,7 @@ func (p *Page) Display_Confirmation(msg *lang.Text) (bool, error) {
 			}
 			fallthrough
 		default:
-			p.Error(errs.ErrInvalidAction, choice)
+			p.Error(errs.ErrInvalidAction.With(choice))
 		}
 	}
 }
@@ -910,7 +1286,7 @@ func (p *Page) GetHelp() []string {
 		rtn = append(rtn, lang.HelpSupportedActions.Text())
 		rtn = append(rtn, symb.Blank.Symbol())
 		for _, v := range p.actions {
-			rtn = append(rtn, symb.Bullet.Symbol()+strg.Upcase(v.Action()))
+			rtn = append(rtn, symb.Bullet.Symbol()+v.Usage())
 		}
 		rtn = append(rtn, symb.Blank.Symbol())
 		rtn = append(rtn, lang.HelpAutoGenerated.Text()+time.Now().Format(time.RFC822))
@@ -0,0 +1,93 @@
+package page
+
+import (
+	"sync"
+
+	conf "github.com/mt1976/crt/config"
+	actn "github.com/mt1976/crt/page/actions"
+)
+
+var (
+	shownLock sync.Mutex
+	shown     *Page // The page on the screen, redrawn when the configuration changes
+
+	pendingLock sync.Mutex
+	pending     *conf.Change // The change waiting to be applied on the goroutine reading input
+
+	// reloads delivers a function applying the pending change to the goroutine reading input, which
+	// runs it between key presses.
+	reloads = make(chan func(), 1)
+)
+
+func init() {
+	conf.OnChange(queueReload)
+}
+
+// setShown records the page that is on the screen.
+func setShown(p *Page) {
+	shownLock.Lock()
+	defer shownLock.Unlock()
+	shown = p
+}
+
+// queueReload records a change in the configuration, to be applied by the goroutine reading input
+// rather than the one that loaded it. Changes made before the last one is applied are combined.
+func queueReload(c conf.Change) {
+	if c.Err != nil {
+		return
+	}
+	pendingLock.Lock()
+	if pending != nil {
+		c.Previous = pending.Previous
+	}
+	pending = &c
+	pendingLock.Unlock()
+	select {
+	case reloads <- reload:
+	default:
+	}
+}
+
+// takeReload returns the change waiting to be applied, if there is one, and forgets it.
+func takeReload() *conf.Change {
+	pendingLock.Lock()
+	defer pendingLock.Unlock()
+	c := pending
+	pending = nil
+	return c
+}
+
+// reload applies the pending change in the configuration to the page on the screen and redraws it,
+// so that the new delay, baud rate, date formats, box style, theme and key bindings take effect.
+func reload() {
+	c := takeReload()
+	if c == nil {
+		return
+	}
+	shownLock.Lock()
+	p := shown
+	shownLock.Unlock()
+	applyReload(p, *c)
+	if p != nil {
+		p.Redraw()
+	}
+}
+
+// applyReload applies a change in the configuration to the page, which may be nil, without drawing
+// it. The key bindings have already been checked by Validate, so binding them does not fail.
+func applyReload(p *Page, c conf.Change) {
+	actn.Bind(c.Current.KeyBindings)
+	if p != nil {
+		p.resetActionLen()
+		p.viewPort.ApplyConfig(c.Previous, c.Current)
+	}
+}
+
+// Redraw draws the whole page again, leaving the cursor where it was.
+func (p *Page) Redraw() {
+	screenLock.Lock()
+	defer screenLock.Unlock()
+	SaveCursor()
+	drawScreen(p)
+	RestoreCursor()
+}
@@ -0,0 +1,59 @@
+package page
+
+import (
+	"errors"
+	"testing"
+
+	conf "github.com/mt1976/crt/config"
+	actn "github.com/mt1976/crt/page/actions"
+	term "github.com/mt1976/crt/terminal"
+)
+
+func Test_queueReload(t *testing.T) {
+	config := func(titleLength int) conf.Config {
+		c := conf.Defaults()
+		c.TitleLength = titleLength
+		return c
+	}
+	defer func() {
+		takeReload()
+		select {
+		case <-reloads:
+		default:
+		}
+	}()
+
+	queueReload(conf.Change{Previous: config(10), Current: config(20)})
+	queueReload(conf.Change{Previous: config(20), Current: config(30)})
+	queueReload(conf.Change{Previous: config(30), Current: config(30), Err: errors.New("bad file")})
+
+	if len(reloads) != 1 {
+		t.Errorf("queueReload() sent %v reloads, want 1", len(reloads))
+	}
+	c := takeReload()
+	if c == nil || c.Previous.TitleLength != 10 || c.Current.TitleLength != 30 {
+		t.Fatalf("takeReload() = %+v, want TitleLength from 10 to 30", c)
+	}
+	if c := takeReload(); c != nil {
+		t.Errorf("takeReload() after taking = %+v, want nil", c)
+	}
+}
+
+func Test_applyReload(t *testing.T) {
+	defer actn.Bind("")
+	p := &Page{viewPort: &term.ViewPort{}}
+	p.AddAction(actn.Quit)
+	p.AddAction(actn.Forward)
+
+	c := conf.Defaults()
+	c.KeyBindings = "Quit:QUIT"
+	applyReload(p, conf.Change{Previous: conf.Defaults(), Current: c})
+	if p.actionLen != 4 || p.findAction("QUIT") != actn.Quit {
+		t.Errorf("applyReload() actionLen = %v, Quit = %v, want 4 and QUIT", p.actionLen, actn.Quit.Action())
+	}
+
+	applyReload(p, conf.Change{Previous: c, Current: conf.Defaults()})
+	if p.actionLen != 1 {
+		t.Errorf("applyReload() back to defaults actionLen = %v, want 1", p.actionLen)
+	}
+}
@@ -0,0 +1,35 @@
+package page
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	term "github.com/mt1976/crt/terminal"
+)
+
+func Test_ShowStatus(t *testing.T) {
+	p := &Page{width: 40, viewPort: &term.ViewPort{}, footerBarTop: 20, footerBarInput: 21, footerBarMessage: 22}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	p.ShowStatus("copying 3 of 10 files")
+	os.Stdout = stdout
+	w.Close()
+	out, _ := io.ReadAll(r)
+
+	for _, row := range []int{p.footerBarInput, p.footerBarMessage} {
+		if strings.Contains(string(out), fmt.Sprintf("\033[%d;", row)) {
+			t.Errorf("ShowStatus() drew on row %v, over the prompt or input", row)
+		}
+	}
+	if !strings.Contains(string(out), fmt.Sprintf("\033[%d;%dH", p.footerBarTop, term.InputColumn)) || !strings.Contains(string(out), "copying 3 of 10 files") {
+		t.Errorf("ShowStatus() = %q, want the status in the footer's top border", out)
+	}
+}
@@ -0,0 +1,152 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	inpt "github.com/mt1976/crt/input"
+	page "github.com/mt1976/crt/page"
+	"golang.org/x/term"
+)
+
+// Group is a set of progress bars for jobs running in parallel, displayed together.
+type Group struct {
+	lock    sync.Mutex
+	bars    []*Progress
+	printed int // The number of lines written by the last inline display
+}
+
+// NewGroup returns a group of the given progress bars.
+func NewGroup(bars ...*Progress) *Group {
+	return &Group{bars: bars}
+}
+
+// Add starts a new progress bar in the group.
+func (g *Group) Add(label string, total int64) *Progress {
+	p := New(label, total)
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.bars = append(g.bars, p)
+	return p
+}
+
+// Bars returns the progress bars in the group.
+func (g *Group) Bars() []*Progress {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return append([]*Progress(nil), g.bars...)
+}
+
+// Done returns true once every job in the group has finished.
+func (g *Group) Done() bool {
+	for _, p := range g.Bars() {
+		if !p.Done() {
+			return false
+		}
+	}
+	return true
+}
+
+// Render returns a line for each progress bar in the group.
+func (g *Group) Render(width int) []string {
+	var lines []string
+	for _, p := range g.Bars() {
+		lines = append(lines, p.Render(width))
+	}
+	return lines
+}
+
+// Summary returns a single line combining every progress bar in the group, for showing where there
+// is room for only one line. A group of one bar is shown as that bar.
+func (g *Group) Summary(width int) string {
+	bars := g.Bars()
+	if len(bars) == 1 {
+		return bars[0].Render(width)
+	}
+	done, percent := 0, 0.0
+	var eta time.Duration
+	for _, p := range bars {
+		if p.Done() {
+			done++
+		}
+		percent += p.Percent()
+		eta = max(eta, p.ETA())
+	}
+	if len(bars) > 0 {
+		percent /= float64(len(bars))
+	}
+	figures := fmt.Sprintf("%3.0f%% %v/%v done", percent, done, len(bars))
+	if eta > 0 {
+		figures += " ETA " + duration(eta)
+	}
+	return line("", percent/100, figures, width)
+}
+
+// Display draws a group of progress bars somewhere.
+type Display func(g *Group)
+
+// Inline draws each bar on its own line at the cursor, redrawing the same lines each time it is called.
+// When output is not a terminal, the bars are written once, when every job has finished.
+func Inline(g *Group) {
+	width := 80
+	if w, _, err := term.GetSize(0); err == nil {
+		width = w - 1
+	}
+	lines := g.Render(width)
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		if g.Done() {
+			fmt.Println(strings.Join(lines, "\n"))
+		}
+		return
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	var out strings.Builder
+	if g.printed > 0 {
+		fmt.Fprintf(&out, "\033[%dA", g.printed)
+	}
+	for _, l := range lines {
+		out.WriteString("\r\033[2K" + l + "\n")
+	}
+	g.printed = len(lines)
+	inpt.Screen.Lock()
+	defer inpt.Screen.Unlock()
+	fmt.Print(out.String())
+}
+
+// Footer draws a summary of the group in the top border of the page's footer, without moving
+// the cursor away from the input field.
+func Footer(p *page.Page) Display {
+	return func(g *Group) {
+		p.ShowStatus(g.Summary(p.StatusWidth()))
+	}
+}
+
+// Start draws the group with display every interval, until the context is cancelled or every job
+// has finished, when it draws the group one last time and closes the returned channel. Inline and
+// Footer hold the screen while they draw, so they can run while the user is typing.
+func (g *Group) Start(ctx context.Context, interval time.Duration, display Display) <-chan struct{} {
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			display(g)
+			if g.Done() {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				display(g)
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return finished
+}
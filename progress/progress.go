@@ -0,0 +1,179 @@
+package progress
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dttm "github.com/mt1976/crt/datesTimes"
+	symb "github.com/mt1976/crt/strings/symbols"
+	wdth "github.com/mt1976/crt/strings/width"
+)
+
+// Progress tracks how far through a known amount of work a job is. It is safe to update from one
+// goroutine while it is displayed from another.
+type Progress struct {
+	lock    sync.Mutex
+	label   string
+	total   int64
+	current int64
+	started time.Time
+	now     func() time.Time
+	Format  func(n int64) string // Formats counts and throughput, such as a number of bytes
+}
+
+// New returns a progress bar for total units of work, starting now.
+func New(label string, total int64) *Progress {
+	return &Progress{
+		label:   label,
+		total:   total,
+		started: time.Now(),
+		now:     time.Now,
+		Format:  func(n int64) string { return strconv.FormatInt(n, 10) },
+	}
+}
+
+// Label returns the label shown before the bar.
+func (p *Progress) Label() string {
+	return p.label
+}
+
+// Add records n more units of work as done.
+func (p *Progress) Add(n int64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.current = min(p.current+n, p.total)
+}
+
+// Increment records one more unit of work as done.
+func (p *Progress) Increment() {
+	p.Add(1)
+}
+
+// Set records the number of units of work done so far.
+func (p *Progress) Set(current int64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.current = min(max(current, 0), p.total)
+}
+
+// SetTotal changes the total units of work, for jobs that discover more as they go.
+func (p *Progress) SetTotal(total int64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.total = total
+	p.current = min(p.current, total)
+}
+
+// Current returns the units of work done and the total.
+func (p *Progress) Current() (int64, int64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.current, p.total
+}
+
+// Done returns true once all the work has been done.
+func (p *Progress) Done() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.current >= p.total
+}
+
+// Percent returns how much of the work has been done, from 0 to 100.
+func (p *Progress) Percent() float64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.percent()
+}
+
+func (p *Progress) percent() float64 {
+	if p.total <= 0 {
+		return 100
+	}
+	return float64(p.current) * 100 / float64(p.total)
+}
+
+// Elapsed returns how long the job has been running.
+func (p *Progress) Elapsed() time.Duration {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.now().Sub(p.started)
+}
+
+// Throughput returns the units of work done per second.
+func (p *Progress) Throughput() float64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.throughput()
+}
+
+func (p *Progress) throughput() float64 {
+	secs := p.now().Sub(p.started).Seconds()
+	if secs <= 0 {
+		return 0
+	}
+	return float64(p.current) / secs
+}
+
+// ETA returns how much longer the job is expected to take at its current throughput, or zero if
+// it has not started or has finished.
+func (p *Progress) ETA() time.Duration {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.eta()
+}
+
+func (p *Progress) eta() time.Duration {
+	rate := p.throughput()
+	if rate <= 0 || p.current >= p.total {
+		return 0
+	}
+	return time.Duration(float64(p.total-p.current) / rate * float64(time.Second))
+}
+
+// Render returns the progress as a single line that fits in width columns, for example
+//
+//	Copying ████████░░░░░░░░  50% 512/1024 64/s ETA 8s 8s
+//
+// The bar shrinks to make room for the figures, and is dropped if there is no room for it.
+func (p *Progress) Render(width int) string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	figures := fmt.Sprintf("%3.0f%% %v/%v %v/s", p.percent(), p.Format(p.current), p.Format(p.total), p.Format(int64(p.throughput())))
+	if eta := p.eta(); eta > 0 {
+		figures += " ETA " + duration(eta)
+	}
+	figures += symb.Space.Symbol() + duration(p.now().Sub(p.started))
+	return line(p.label, p.percent()/100, figures, width)
+}
+
+// line lays out a label, a bar filled to fraction and the figures in width columns.
+func line(label string, fraction float64, figures string, width int) string {
+	space := symb.Space.Symbol()
+	barWidth := width - wdth.Of(label) - wdth.Of(figures) - 2
+	if label == "" {
+		barWidth++
+	}
+	if barWidth < 5 {
+		return wdth.Truncate(strings.TrimSpace(label+space+figures), width)
+	}
+	out := bar(fraction, barWidth) + space + figures
+	if label != "" {
+		out = label + space + out
+	}
+	return out
+}
+
+// bar returns a bar of the given width, filled in proportion to fraction.
+func bar(fraction float64, width int) string {
+	filled := int(fraction * float64(width))
+	filled = min(max(filled, 0), width)
+	return strings.Repeat(symb.GaugeFull.Symbol(), filled) + strings.Repeat(symb.GaugeEmpty.Symbol(), width-filled)
+}
+
+// duration formats a duration to the nearest second.
+func duration(d time.Duration) string {
+	return dttm.FormatDuration(d.Round(time.Second))
+}
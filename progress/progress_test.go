@@ -0,0 +1,59 @@
+package progress
+
+import (
+	"testing"
+	"time"
+
+	wdth "github.com/mt1976/crt/strings/width"
+)
+
+// clock returns a progress bar whose clock stands still, elapsed after it started.
+func clock(label string, total int64, elapsed time.Duration) *Progress {
+	p := New(label, total)
+	p.now = func() time.Time { return p.started.Add(elapsed) }
+	return p
+}
+
+func Test_Progress(t *testing.T) {
+	p := clock("Copying", 100, 10*time.Second)
+	p.Add(25)
+
+	if got := p.Percent(); got != 25 {
+		t.Errorf("Percent() = %v, want 25", got)
+	}
+	if got := p.Throughput(); got != 2.5 {
+		t.Errorf("Throughput() = %v, want 2.5", got)
+	}
+	if got := p.ETA(); got != 30*time.Second {
+		t.Errorf("ETA() = %v, want 30s", got)
+	}
+	if got, want := p.Render(50), "Copying ███░░░░░░░░░░░  25% 25/100 2/s ETA 30s 10s"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+	if got := p.Render(20); wdth.Of(got) > 20 {
+		t.Errorf("Render() = %q does not fit in 20 columns", got)
+	}
+
+	p.Add(200)
+	if !p.Done() || p.ETA() != 0 {
+		t.Errorf("Add() past the total did not finish, current %v", p.current)
+	}
+}
+
+func Test_Summary(t *testing.T) {
+	a := clock("a", 10, time.Second)
+	b := clock("b", 10, time.Second)
+	a.Set(10)
+	b.Set(5)
+	g := NewGroup(a, b)
+
+	if got, want := g.Summary(40), "██████████████░░░░░  75% 1/2 done ETA 1s"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+	if g.Done() {
+		t.Errorf("Done() = true with a job unfinished")
+	}
+	if got := len(g.Render(40)); got != 2 {
+		t.Errorf("Render() = %v lines, want 2", got)
+	}
+}
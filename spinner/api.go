@@ -1,7 +1,11 @@
 package spinner
 
 import (
+	"context"
 	"log"
+
+	page "github.com/mt1976/crt/page"
+	symb "github.com/mt1976/crt/strings/symbols"
 )
 
 // New returns a new Spinner
@@ -14,7 +18,7 @@ func New() *Spinner {
 // Tick advances the spinner to the next state
 // The `Tick()` function is a method of the `Spinner` type. It advances the spinner to the next state
 // without displaying any message. It calls the `tick()` method of the `Spinner` instance with an empty
-// string as the message parameter.
+// string as the message parameter. It does nothing while the spinner is started.
 func (s *Spinner) Tick() {
 	s.tick("")
 }
@@ -22,23 +26,82 @@ func (s *Spinner) Tick() {
 // TickWithMessage advances the spinner to the next state and displays a message
 // The `TickWithMessage` function is a method of the `Spinner` type. It advances the spinner to the
 // next state and displays a message. It calls the `tick` method of the `Spinner` instance with the
-// `message` parameter.
+// `message` parameter. It does nothing while the spinner is started; use SetMessage instead.
 func (s *Spinner) TickWithMessage(message string) {
 	s.tick(message)
 }
 
 // Style sets the style of the spinner
 // The `Style` method is a function of the `Spinner` type. It sets the style of the spinner by calling
-// the `setStyle` method of the `Spinner` instance with the `style` parameter. It then returns the
-// `Spinner` instance.
-func (s *Spinner) Style(style framesIndex) *Spinner {
+// the `setStyle` method of the `Spinner` instance with the name of the style, which may be one of
+// `s.Styles` or any name given to Register. It then returns the `Spinner` instance.
+func (s *Spinner) Style(style string) *Spinner {
+	loadConfiguredFrameSets()
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	return s.setStyle(style)
 }
 
-//func (s *Spinner) SetLocation(row int, column int) *Spinner {
-//	// ...
-//	return s.setLocation(row, column)
-//}
+// SetLocation sets the row and column the spinner is drawn at, rather than the cursor position.
+// The cursor is returned to where it was after each frame.
+func (s *Spinner) SetLocation(row int, column int) *Spinner {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.setLocation(row, column)
+}
+
+// InHeader draws the spinner in the page's header, between the application name and the title.
+func (s *Spinner) InHeader(p *page.Page) *Spinner {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.draw = p.ShowHeaderStatus
+	return s
+}
+
+// InFooter draws the spinner in the top border of the page's footer.
+func (s *Spinner) InFooter(p *page.Page) *Spinner {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.draw = p.ShowStatus
+	return s
+}
+
+// Start animates the spinner in the background until Stop, Succeed or Fail is called, or the context
+// is cancelled. The spinner moves on a frame every Delay, or every 100ms if no delay has been set.
+func (s *Spinner) Start(ctx context.Context) *Spinner {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.cancel != nil {
+		return s
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+	go s.run(ctx, s.done, s.slow)
+	return s
+}
+
+// SetMessage sets the message shown after a started spinner.
+func (s *Spinner) SetMessage(message string) *Spinner {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.message = message
+	return s
+}
+
+// Stop stops a started spinner and replaces it with the final message.
+func (s *Spinner) Stop(finalMessage string) {
+	s.finish(symb.Space.Symbol(), finalMessage)
+}
+
+// Succeed stops a started spinner and replaces it with a success mark and the final message.
+func (s *Spinner) Succeed(finalMessage string) {
+	s.finish(symb.SuccessMark.Symbol(), finalMessage)
+}
+
+// Fail stops a started spinner and replaces it with a failure mark and the final message.
+func (s *Spinner) Fail(finalMessage string) {
+	s.finish(symb.FailureMark.Symbol(), finalMessage)
+}
 
 // Debug prints debug information to stdout
 // The `Debug()` function is a method of the `Spinner` type. It is used to print debug information to
@@ -61,6 +124,8 @@ func (s *Spinner) Debug() {
 // seconds. It calls the `setDelay` method of the `Spinner` instance with the `seconds` parameter to
 // set the delay. Finally, it returns the `Spinner` instance to allow for method chaining.
 func (s *Spinner) Delay(seconds float64) *Spinner {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	s.setDelay(seconds)
 	return s
 }
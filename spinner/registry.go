@@ -0,0 +1,181 @@
+package spinner
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+)
+
+// FrameSet is a named set of spinner frames, and the time each frame is shown for. An interval of
+// zero leaves the speed to the spinner.
+type FrameSet struct {
+	Name     string
+	Frames   []string
+	Interval time.Duration
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[string]FrameSet{}
+
+	configLock   sync.Mutex
+	configLoaded bool   // True once the configured SpinnerFile has been loaded, until the configuration changes
+	configFile   string // The SpinnerFile last loaded
+	configErr    error  // Why the SpinnerFile could not be loaded, if it could not
+)
+
+func init() {
+	builtIn := []FrameSet{
+		{"Default", []string{"-", "\\", "|", "/"}, 0},
+		{"Plus", []string{"+", "x"}, 0},
+		{"Directions", []string{"v", "<", "^", ">"}, 0},
+		{"Dots", []string{".   ", " .  ", "  . ", "   ."}, 0},
+		{"Ball", []string{"◐", "◓", "◑", "◒"}, 0},
+		{"SquareClock", []string{"◰", "◳", "◲", "◱"}, 0},
+		{"Clock", []string{"◴", "◷", "◶", "◵"}, 0},
+		{"Snake", []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}, 0},
+		{"ChasingDots", []string{".  ", ".. ", "...", " ..", "  .", "   "}, 0},
+		{"Arrows", []string{"←", "↖", "↑", "↗", "→", "↘", "↓", "↙"}, 0},
+		{"Grow", []string{"▁", "▃", "▄", "▅", "▆", "▇", "█", "▇", "▆", "▅", "▄", "▃"}, 0},
+		{"Cross", []string{"┤", "┘", "┴", "└", "├", "┌", "┬", "┐"}, 0},
+		{"Flip", []string{"_", "_", "_", "-", "`", "`", "'", "´", "-", "_", "_", "_"}, 0},
+		{"Cylon", []string{"( ●    )", "(  ●   )", "(   ●  )", "(    ● )", "(     ●)", "(    ● )", "(   ●  )", "(  ●   )", "( ●    )", "(●     )"}, 0},
+		{"DirectionsSlow", []string{"<", "<", "∧", "∧", ">", ">", "v", "v"}, 0},
+	}
+	for _, set := range builtIn {
+		Register(set.Name, set.Frames, set.Interval)
+	}
+	// Read the spinner file again when the configuration, or the file itself, changes
+	conf.OnChange(func(conf.Change) {
+		configLock.Lock()
+		defer configLock.Unlock()
+		configLoaded = false
+	})
+	conf.AddCheck("SpinnerFile", func(c conf.Config) error {
+		if c.SpinnerFile == "" {
+			return nil
+		}
+		_, err := readFrameSets(c.SpinnerFile)
+		return err
+	})
+}
+
+// Register adds a named set of frames that spinners can use with Style, replacing any set already
+// registered with the same name. Names are not case sensitive.
+func Register(name string, frames []string, interval time.Duration) error {
+	if err := checkFrameSet(name, frames); err != nil {
+		return err
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry[strings.ToLower(name)] = FrameSet{Name: name, Frames: frames, Interval: interval}
+	return nil
+}
+
+// checkFrameSet returns an error if a frame set has no name or no frames.
+func checkFrameSet(name string, frames []string) error {
+	if strings.TrimSpace(name) == "" || len(frames) == 0 {
+		return errs.ErrInvalidSpinner.With(strconv.Quote(name)).Wrap(errors.New("a spinner needs a name and at least one frame"))
+	}
+	return nil
+}
+
+// Available returns the names of the registered spinner styles, in alphabetical order.
+func Available() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	var names []string
+	for _, set := range registry {
+		names = append(names, set.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup returns the registered frame set with the given name.
+func lookup(name string) (FrameSet, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	set, ok := registry[strings.ToLower(name)]
+	return set, ok
+}
+
+// frameSetFile is the layout of a frame set stored as JSON, for example
+//
+//	[{"name": "Acme", "frames": ["a", "c", "m", "e"], "interval": "150ms"}]
+type frameSetFile struct {
+	Name     string   `json:"name"`
+	Frames   []string `json:"frames"`
+	Interval string   `json:"interval"`
+}
+
+// LoadFrameSets registers the frame sets in a JSON file.
+func LoadFrameSets(path string) error {
+	sets, err := readFrameSets(path)
+	if err != nil {
+		return err
+	}
+	for _, set := range sets {
+		if err := Register(set.Name, set.Frames, set.Interval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readFrameSets reads the frame sets in a JSON file, without registering them.
+func readFrameSets(path string) ([]FrameSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sets []frameSetFile
+	if err := json.Unmarshal(data, &sets); err != nil {
+		return nil, errs.ErrInvalidSpinner.With(path).Wrap(err)
+	}
+	var read []FrameSet
+	for _, set := range sets {
+		var interval time.Duration
+		if set.Interval != "" {
+			if interval, err = time.ParseDuration(set.Interval); err != nil {
+				return nil, errs.ErrInvalidSpinner.With(path).Wrap(err)
+			}
+		}
+		if err := checkFrameSet(set.Name, set.Frames); err != nil {
+			return nil, err
+		}
+		read = append(read, FrameSet{Name: set.Name, Frames: set.Frames, Interval: interval})
+	}
+	return read, nil
+}
+
+// loadConfiguredFrameSets registers the frame sets in the file named by SpinnerFile in the
+// configuration, the first time a spinner is created or styled after the configuration is loaded. It
+// returns the error from loading the file, which LoadError also returns.
+func loadConfiguredFrameSets() error {
+	file := conf.Current().SpinnerFile
+	configLock.Lock()
+	defer configLock.Unlock()
+	if configLoaded && file == configFile {
+		return configErr
+	}
+	configLoaded, configFile, configErr = true, file, nil
+	if file != "" {
+		configErr = LoadFrameSets(file)
+	}
+	return configErr
+}
+
+// LoadError returns why the frame sets in the file named by SpinnerFile in the configuration could
+// not be loaded, or nil if they were, or no file is named.
+func LoadError() error {
+	return loadConfiguredFrameSets()
+}
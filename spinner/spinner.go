@@ -1,16 +1,22 @@
 package spinner
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
+
+	inpt "github.com/mt1976/crt/input"
+	wdth "github.com/mt1976/crt/strings/width"
 )
 
-type framesIndex int
+// defaultInterval is the time between frames of a started spinner, if no delay has been set.
+const defaultInterval = 100 * time.Millisecond
 
 // The Spinner type represents a spinning animation with various styles and settings.
-// @property {framesIndex} style - The style property is of type framesIndex, which is used to
-// determine the style of the spinner. It is an index that points to a specific style in the
-// spinnerStyles struct.
+// @property {string} style - The style property is the name of the registered frame set used by
+// the spinner, such as one of the names in the spinnerStyles struct.
 // @property {int} row - The `row` property represents the current row position of the spinner. It is
 // used to determine where the spinner should be displayed on the screen or in a terminal window.
 // @property {int} column - The `column` property represents the column position of the spinner in a
@@ -28,37 +34,46 @@ type framesIndex int
 // @property Styles - The `Styles` property is a pointer to a `spinnerStyles` struct.
 type Spinner struct {
 	// ...
-	style    framesIndex
-	row      int
-	column   int
-	frames   []string
-	cycle    int
-	sequence int
-	slow     time.Duration
-	Styles   *spinnerStyles
+	lock      sync.Mutex
+	style     string
+	row       int
+	column    int
+	frames    []string
+	cycle     int
+	sequence  int
+	slow      time.Duration
+	Styles    *spinnerStyles
+	message   string             // the message shown after the frame by a started spinner
+	saved     bool               // true once the cursor position has been saved for inline output
+	lastWidth int                // the width of the last output, so a shorter one can blank it out
+	delaySet  bool               // true if Delay has been called, so the style's interval is not used
+	draw      func(text string)  // draws the spinner somewhere other than the cursor, such as a page
+	cancel    context.CancelFunc // stops a started spinner
+	done      chan struct{}      // closed when a started spinner has stopped
 }
 
 type spinnerStyles struct {
 	// ...
-	Default        framesIndex
-	Plus           framesIndex
-	Directions     framesIndex
-	Dots           framesIndex
-	Ball           framesIndex
-	SquareClock    framesIndex
-	Clock          framesIndex
-	Snake          framesIndex
-	ChasingDots    framesIndex
-	Arrows         framesIndex
-	Grow           framesIndex
-	Cross          framesIndex
-	Flip           framesIndex
-	Cylon          framesIndex
-	DirectionsSlow framesIndex
+	Default        string
+	Plus           string
+	Directions     string
+	Dots           string
+	Ball           string
+	SquareClock    string
+	Clock          string
+	Snake          string
+	ChasingDots    string
+	Arrows         string
+	Grow           string
+	Cross          string
+	Flip           string
+	Cylon          string
+	DirectionsSlow string
 }
 
 // new returns a new Spinner, with defaults
 func new() *Spinner {
+	loadConfiguredFrameSets()
 	sp := &Spinner{row: 0, column: 0}
 	sp.sequence = 0
 	sp.slow = 0
@@ -70,34 +85,43 @@ func new() *Spinner {
 }
 
 // The `tick` function is responsible for advancing the spinner to the next state and displaying the
-// current frame of the spinner animation.
+// current frame of the spinner animation. It does nothing while the spinner is started, as it is
+// already being advanced in the background.
 func (s *Spinner) tick(msg string) {
 	// ...
-	//	log.Println("tick")
+	s.lock.Lock()
+	if s.cancel != nil {
+		s.lock.Unlock()
+		return
+	}
 	s.sequence = (s.sequence + 1)
 	if s.sequence >= s.cycle {
 		s.sequence = 0
 	}
-	//	log.Println("sequence:", s.sequence)
-	fmt.Print("\033[u\033[K[" + s.frames[s.sequence] + "] " + msg)
-	if s.slow > 0 {
-		//		log.Println("sleeping")
-		time.Sleep(s.slow)
+	s.render(s.frames[s.sequence], msg)
+	slow := s.slow
+	s.lock.Unlock()
+	if slow > 0 {
+		time.Sleep(slow)
 	}
 }
 
 // setStyle sets the style of the spinner
-// The `setStyle` function is a method of the `Spinner` struct. It takes a `style` parameter of type
-// `framesIndex` and sets the `style` property of the `Spinner` to the provided value. It then updates
-// the `frames` property of the `Spinner` by calling the `getFrames` method with the new style. The
-// `cycle` property is updated to the length of the new frames, and the `sequence` property is reset to
-// 0. Finally, it returns a pointer to the updated `Spinner` object.
-func (s *Spinner) setStyle(style framesIndex) *Spinner {
+// The `setStyle` function is a method of the `Spinner` struct. It takes the name of a style and sets
+// the `style` property of the `Spinner` to it. It then updates the `frames` property of the `Spinner`
+// by calling the `getFrames` method with the new style. The `cycle` property is updated to the length
+// of the new frames, and the `sequence` property is reset to 0. If the style has its own interval,
+// and no delay has been set, the interval is used as the delay between frames. Finally, it returns a
+// pointer to the updated `Spinner` object.
+func (s *Spinner) setStyle(style string) *Spinner {
 	// ...
 	s.style = style
 	s.frames = s.getFrames(style)
 	s.cycle = len(s.frames)
 	s.sequence = 0
+	if set, ok := lookup(style); ok && set.Interval > 0 && !s.delaySet {
+		s.slow = set.Interval
+	}
 	return s
 }
 
@@ -111,82 +135,106 @@ func (s *Spinner) setLocation(row int, column int) *Spinner {
 	return s
 }
 
-// getFrames returns the characters for a given style
-// The `getFrames` function is a method of the `Spinner` struct. It takes a `style` parameter of type
-// `framesIndex` and returns a slice of strings representing the frames of the spinner animation for
-// the given style.
-func (s *Spinner) getFrames(style framesIndex) []string {
+// render shows a frame and message. A spinner drawn on a page or at a location leaves the cursor where
+// it was; otherwise it is drawn at the cursor position saved when it was first shown. The screen is
+// held while it is drawn, so a started spinner does not break into the input being drawn.
+func (s *Spinner) render(frame, msg string) {
+	text := "[" + frame + "] " + msg
+	if pad := s.lastWidth - wdth.Of(text); pad > 0 && (s.draw != nil || s.row > 0) {
+		text = text + strings.Repeat(" ", pad)
+	}
+	s.lastWidth = wdth.Of(text)
+	switch {
+	case s.draw != nil:
+		s.draw(text)
+	case s.row > 0:
+		inpt.Screen.Lock()
+		defer inpt.Screen.Unlock()
+		fmt.Printf("\0337\033[%d;%dH%s\0338", s.row, max(s.column, 1), text)
+	default:
+		inpt.Screen.Lock()
+		defer inpt.Screen.Unlock()
+		if !s.saved {
+			fmt.Print("\033[s")
+			s.saved = true
+		}
+		fmt.Print("\033[u\033[K" + text)
+	}
+}
+
+// run advances the spinner every interval until the context is cancelled.
+func (s *Spinner) run(ctx context.Context, done chan struct{}, interval time.Duration) {
+	defer close(done)
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.lock.Lock()
+		s.sequence = (s.sequence + 1) % s.cycle
+		s.render(s.frames[s.sequence], s.message)
+		s.lock.Unlock()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
 
-	rtn := []string{"-", "\\", "|", "/"}
+// finish stops a started spinner and shows the final frame and message.
+func (s *Spinner) finish(frame, msg string) {
+	s.lock.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.lock.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.render(frame, msg)
+	if s.draw == nil && s.row == 0 {
+		fmt.Println()
+		s.saved = false
+	}
+}
 
-	switch style {
-	case s.Styles.Default:
-	//	rtn = []string{"-", "\\", "|", "/"}
-	case s.Styles.Plus:
-		rtn = []string{"+", "x"}
-	case s.Styles.Directions:
-		rtn = []string{"v", "<", "^", ">"}
-	case s.Styles.Dots:
-		rtn = []string{".   ", " .  ", "  . ", "   ."}
-	case s.Styles.Ball:
-		rtn = []string{"◐", "◓", "◑", "◒"}
-	case s.Styles.SquareClock:
-		rtn = []string{"◰", "◳", "◲", "◱"}
-	case s.Styles.Clock:
-		rtn = []string{"◴", "◷", "◶", "◵"}
-	case s.Styles.Snake:
-		rtn = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-	case s.Styles.ChasingDots:
-		rtn = []string{".  ", ".. ", "...", " ..", "  .", "   "}
-	case s.Styles.Arrows:
-		rtn = []string{"←", "↖", "↑", "↗", "→", "↘", "↓", "↙"}
-	case s.Styles.Grow:
-		rtn = []string{"▁", "▃", "▄", "▅", "▆", "▇", "█", "▇", "▆", "▅", "▄", "▃"}
-	case s.Styles.Cross:
-		rtn = []string{"┤", "┘", "┴", "└", "├", "┌", "┬", "┐"}
-	case s.Styles.Flip:
-		rtn = []string{"_", "_", "_", "-", "`", "`", "'", "´", "-", "_", "_", "_"}
-	case s.Styles.Cylon:
-		rtn = []string{"( ●    )",
-			"(  ●   )",
-			"(   ●  )",
-			"(    ● )",
-			"(     ●)",
-			"(    ● )",
-			"(   ●  )",
-			"(  ●   )",
-			"( ●    )",
-			"(●     )"}
-	case s.Styles.DirectionsSlow:
-		rtn = []string{"<", "<", "∧", "∧", ">", ">", "v", "v"}
-	default:
-		//rtn = []string{"-", "\\", "|", "/"}
+// getFrames returns the characters for a given style
+// The `getFrames` function is a method of the `Spinner` struct. It takes the name of a style and
+// returns a slice of strings representing the frames of the spinner animation for that style. An
+// unknown name gives the default frames.
+func (s *Spinner) getFrames(style string) []string {
+	set, ok := lookup(style)
+	if !ok {
+		set, _ = lookup(s.Styles.Default)
 	}
-	// ...
-	return rtn
+	return set.Frames
 }
 
 // initialiseStyles sets the default styles
 // The function `initialiseStyles()` initializes and returns a pointer to a `spinnerStyles` struct with
-// predefined values for different spinner styles.
+// the names of the built in spinner styles.
 func initialiseStyles() *spinnerStyles {
 	// ...
 	s := &spinnerStyles{}
-	s.Default = 1
-	s.Plus = 2
-	s.Directions = 3
-	s.Dots = 4
-	s.Ball = 5
-	s.SquareClock = 6
-	s.Clock = 7
-	s.Snake = 8
-	s.ChasingDots = 9
-	s.Arrows = 10
-	s.Grow = 11
-	s.Cross = 12
-	s.Flip = 13
-	s.Cylon = 14
-	s.DirectionsSlow = 15
+	s.Default = "Default"
+	s.Plus = "Plus"
+	s.Directions = "Directions"
+	s.Dots = "Dots"
+	s.Ball = "Ball"
+	s.SquareClock = "SquareClock"
+	s.Clock = "Clock"
+	s.Snake = "Snake"
+	s.ChasingDots = "ChasingDots"
+	s.Arrows = "Arrows"
+	s.Grow = "Grow"
+	s.Cross = "Cross"
+	s.Flip = "Flip"
+	s.Cylon = "Cylon"
+	s.DirectionsSlow = "DirectionsSlow"
 	return s
 }
 
@@ -197,5 +245,6 @@ func (s *Spinner) setDelay(seconds float64) *Spinner {
 	nanos := time.Second.Nanoseconds()
 	seconds = float64(nanos) * seconds
 	s.slow = time.Duration(seconds)
+	s.delaySet = true
 	return s
 }
@@ -0,0 +1,129 @@
+package spinner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+)
+
+func Test_StartStop(t *testing.T) {
+	tests := []struct {
+		name   string
+		finish func(s *Spinner)
+		want   string
+	}{
+		{"Succeed", func(s *Spinner) { s.Succeed("copied") }, "[✓] copied"},
+		{"Fail", func(s *Spinner) { s.Fail("failed") }, "[✗] failed"},
+		{"Stop", func(s *Spinner) { s.Stop("stopped") }, "[ ] stopped"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lock sync.Mutex
+			var drawn []string
+			s := New().Delay(0.001)
+			s.draw = func(text string) {
+				lock.Lock()
+				defer lock.Unlock()
+				drawn = append(drawn, text)
+			}
+			s.SetMessage("copying").Start(context.Background())
+			time.Sleep(20 * time.Millisecond)
+			tt.finish(s)
+
+			lock.Lock()
+			defer lock.Unlock()
+			if len(drawn) < 3 {
+				t.Fatalf("spinner drew %v frames, want several", len(drawn))
+			}
+			if !strings.HasSuffix(drawn[0], "] copying") {
+				t.Errorf("first frame = %q, want the message", drawn[0])
+			}
+			if got := strings.TrimRight(drawn[len(drawn)-1], " "); got != tt.want {
+				t.Errorf("final frame = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Registry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spinners.json")
+	data := `[{"name": "Acme", "frames": ["a", "c", "m", "e"], "interval": "150ms"}]`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadFrameSets(path); err != nil {
+		t.Fatalf("LoadFrameSets() error = %v", err)
+	}
+	if !slices.Contains(Available(), "Acme") || !slices.Contains(Available(), "Cylon") {
+		t.Errorf("Available() = %v, want Acme and the built in styles", Available())
+	}
+
+	s := New().Style("acme")
+	if !reflect.DeepEqual(s.frames, []string{"a", "c", "m", "e"}) || s.slow != 150*time.Millisecond {
+		t.Errorf("Style(acme) frames = %v, interval = %v", s.frames, s.slow)
+	}
+	if s := New().Delay(1).Style("acme"); s.slow != time.Second {
+		t.Errorf("Style() replaced the delay, interval = %v", s.slow)
+	}
+	if s := New().Style(New().Styles.Ball); s.frames[0] != "◐" {
+		t.Errorf("Style(Styles.Ball) frames = %v", s.frames)
+	}
+	if s := New().Style("nonesuch"); s.frames[0] != "-" {
+		t.Errorf("Style() of an unknown name frames = %v, want the default", s.frames)
+	}
+	if err := Register("Empty", nil, 0); !errors.Is(err, errs.ErrInvalidSpinner) {
+		t.Errorf("Register() with no frames error = %v", err)
+	}
+}
+
+func Test_ConfiguredFrameSets(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "spinners.json")
+	os.WriteFile(filepath.Join(dir, "terminal.env"), []byte("SpinnerFile="+path+"\n"), 0o644)
+	opts := conf.Options{Paths: []string{dir}, UserPaths: []string{}, SystemPaths: []string{}}
+	defer conf.Load(conf.Options{Paths: []string{}, UserPaths: []string{}, SystemPaths: []string{}})
+
+	os.WriteFile(path, []byte(`not json`), 0o644)
+	if err := conf.Load(opts); !errors.Is(err, errs.ErrInvalidConfig) || !errors.Is(err, errs.ErrInvalidSpinner) {
+		t.Errorf("Load() with a broken SpinnerFile = %v, want ErrInvalidConfig and ErrInvalidSpinner", err)
+	}
+
+	// A file broken after the configuration was loaded is reported by LoadError
+	os.WriteFile(path, []byte(`[{"name": "Branded", "frames": ["b"]}]`), 0o644)
+	if err := conf.Load(opts); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(path, []byte(`not json`), 0o644)
+	if err := LoadError(); !errors.Is(err, errs.ErrInvalidSpinner) {
+		t.Errorf("LoadError() = %v, want ErrInvalidSpinner", err)
+	}
+	os.WriteFile(path, []byte(`[{"name": "Branded", "frames": ["b"]}]`), 0o644)
+	if err := conf.Load(opts); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadError(); err != nil || !slices.Contains(Available(), "Branded") {
+		t.Errorf("LoadError() after reloading = %v, Available() = %v, want Branded", err, Available())
+	}
+}
+
+func Test_StyleWhileRunning(t *testing.T) {
+	s := New()
+	s.draw = func(string) {} // Style and TickWithMessage must not race with the running spinner
+	s.Start(context.Background())
+	for _, style := range []string{"Ball", "Snake", "Cylon"} {
+		s.Style(style)
+		s.TickWithMessage(style)
+		time.Sleep(20 * time.Millisecond)
+	}
+	s.Stop("")
+}
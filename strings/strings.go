@@ -5,10 +5,9 @@ import (
 	"strings"
 
 	colour "github.com/fatih/color"
+	locl "github.com/mt1976/crt/language/locale"
 	numb "github.com/mt1976/crt/numbers"
 	symb "github.com/mt1976/crt/strings/symbols"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 	"golang.org/x/text/number"
 )
 
@@ -82,7 +81,8 @@ func CleanContent(msg string) string {
 }
 
 // The `humanNumber` method of the `Crt` struct is used to convert a value to a human-readable string. It
-// takes a parameter `v` of type `any`, which means it can accept any type of value.
+// takes a parameter `v` of type `any`, which means it can accept any type of value. Numbers are written
+// with the thousands separator and decimal mark of the active locale.
 func Human(v any) string {
 	if v == nil {
 		return ""
@@ -90,7 +90,7 @@ func Human(v any) string {
 
 	//T.Basic(fmt.Sprintf("Type: %T", v))
 
-	p := message.NewPrinter(language.English)
+	p := locl.Current().Printer()
 
 	switch v.(type) {
 	case int, int8, int16, uint, uint8, uint16, int32, int64, uint64:
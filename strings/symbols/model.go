@@ -1,6 +1,10 @@
 package symbols
 
-import "strings"
+import (
+	"strings"
+
+	wdth "github.com/mt1976/crt/strings/width"
+)
 
 type Symbol struct {
 	content string
@@ -10,7 +14,7 @@ type Symbol struct {
 func New(content string) *Symbol {
 	return &Symbol{
 		content: content,
-		len:     len(content),
+		len:     wdth.Of(content),
 	}
 }
 
@@ -27,4 +27,9 @@ var (
 	SymLinkID        *Symbol = New("L")
 	ConfigDelimiter  *Symbol = New("|")
 	TextDelimiter    *Symbol = New(" - ")
+	GaugeFull        *Symbol = New("█")
+	GaugeEmpty       *Symbol = New("░")
+	StatusDot        *Symbol = New("●")
+	SuccessMark      *Symbol = New("✓")
+	FailureMark      *Symbol = New("✗")
 )
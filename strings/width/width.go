@@ -0,0 +1,174 @@
+// Package width measures, truncates and pads strings by the number of columns they occupy on a
+// terminal, rather than the number of bytes they contain. ANSI escape sequences take no columns,
+// combining marks and other zero width characters take none, and East Asian wide and fullwidth
+// characters, including most emoji, take two.
+package width
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+const (
+	escape = '\x1b'
+	reset  = "\x1b[0m"
+)
+
+// Rune returns the number of columns occupied by r.
+func Rune(r rune) int {
+	switch {
+	case r == 0:
+		return 0
+	case r < 32 || (r >= 0x7f && r < 0xa0):
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case r >= 0x1160 && r <= 0x11ff: // Hangul medial vowels and final consonants combine with the syllable
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// Of returns the number of columns occupied by s, ignoring any ANSI escape sequences.
+func Of(s string) int {
+	cols := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		cols += Rune(r)
+		i += size
+	}
+	return cols
+}
+
+// Strip returns s with all ANSI escape sequences removed.
+func Strip(s string) string {
+	if !strings.ContainsRune(s, escape) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		b.WriteString(s[i : i+size])
+		i += size
+	}
+	return b.String()
+}
+
+// Split splits s into a head occupying at most cols columns and the remaining tail. Escape sequences
+// are kept with the text they precede, and a character is never split.
+func Split(s string, cols int) (string, string) {
+	used := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w := Rune(r)
+		if used+w > cols {
+			return s[:i], s[i:]
+		}
+		used += w
+		i += size
+	}
+	return s, ""
+}
+
+// Truncate shortens s to at most cols columns. If s is styled and is shortened, the style is reset at
+// the end so it does not spill over into whatever is printed next.
+func Truncate(s string, cols int) string {
+	head, tail := Split(s, cols)
+	if tail == "" {
+		return s
+	}
+	if strings.ContainsRune(head, escape) {
+		head = head + reset
+	}
+	return head
+}
+
+// TruncateWith shortens s to at most cols columns, ending it with tail if it has to be shortened.
+func TruncateWith(s string, cols int, tail string) string {
+	if Of(s) <= cols {
+		return s
+	}
+	return Truncate(s, cols-Of(tail)) + tail
+}
+
+// PadRight pads s with spaces on the right until it occupies cols columns.
+func PadRight(s string, cols int) string {
+	if pad := cols - Of(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// PadLeft pads s with spaces on the left until it occupies cols columns.
+func PadLeft(s string, cols int) string {
+	if pad := cols - Of(s); pad > 0 {
+		return strings.Repeat(" ", pad) + s
+	}
+	return s
+}
+
+// Centre pads s with spaces on both sides so it is centred within cols columns.
+func Centre(s string, cols int) string {
+	left := Offset(s, cols)
+	return PadRight(strings.Repeat(" ", left)+s, cols)
+}
+
+// Offset returns the number of columns before s when it is centred within cols columns.
+func Offset(s string, cols int) int {
+	if left := (cols - Of(s)) / 2; left > 0 {
+		return left
+	}
+	return 0
+}
+
+// Fit truncates or pads s so it occupies exactly cols columns.
+func Fit(s string, cols int) string {
+	return PadRight(Truncate(s, cols), cols)
+}
+
+// escapeLen returns the length of the ANSI escape sequence at the start of s, or 0 if s does not
+// start with one.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != escape {
+		return 0
+	}
+	switch s[1] {
+	case '[': // Control Sequence Introducer, ends with a byte in the range @ to ~
+		for i := 2; i < len(s); i++ {
+			if s[i] >= '@' && s[i] <= '~' {
+				return i + 1
+			}
+		}
+		return len(s)
+	case ']': // Operating System Command, ends with BEL or ESC \
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == escape && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	}
+	return 2
+}
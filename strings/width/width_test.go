@@ -0,0 +1,95 @@
+package width
+
+import (
+	"testing"
+)
+
+const (
+	bold = "\x1b[1m"
+	red  = "\x1b[1;31m"
+)
+
+func Test_Of(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want int
+	}{
+		{"Empty", "", 0},
+		{"ASCII", "Hello", 5},
+		{"Styled", red + "ERROR " + reset + "failed", 12},
+		{"Nested styles", bold + red + "ab" + reset + reset, 2},
+		{"Heavy box drawing", "┏━━┓", 4},
+		{"Accented", "café", 4},
+		{"Combining mark", "cafe\u0301", 4},
+		{"CJK", "日本語", 6},
+		{"Fullwidth", "ＡＢ", 4},
+		{"Emoji", "ok 👍", 5},
+		{"Emoji with variation selector", "❤️", 1},
+		{"Zero width joiner", "a‍b", 2},
+		{"Operating system command", "\x1b]0;title\a" + "x", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Of(tt.in); got != tt.want {
+				t.Errorf("Of(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Truncate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		cols int
+		want string
+	}{
+		{"Short enough", "abc", 5, "abc"},
+		{"ASCII", "abcdef", 3, "abc"},
+		{"Multibyte is not cut in half", "héllo", 2, "hé"},
+		{"Wide character does not fit", "日本語", 3, "日"},
+		{"Styled is reset", red + "abcdef" + reset, 3, red + "abc" + reset},
+		{"Styled fits", red + "abc" + reset, 3, red + "abc" + reset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.in, tt.cols); got != tt.want {
+				t.Errorf("Truncate(%q, %v) = %q, want %q", tt.in, tt.cols, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Pad(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"PadRight styled", PadRight(bold+"ab"+reset, 4), bold + "ab" + reset + "  "},
+		{"PadRight wide", PadRight("日本", 6), "日本  "},
+		{"PadLeft", PadLeft("é", 3), "  é"},
+		{"Centre", Centre("┃x┃", 7), "  ┃x┃  "},
+		{"Fit long", Fit("abcdef", 4), "abcd"},
+		{"Fit short", Fit("ab", 4), "ab  "},
+		{"TruncateWith", TruncateWith("Testing testing 123", 10, "..."), "Testing..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Split(t *testing.T) {
+	head, tail := Split(bold+"日本"+reset+"語", 4)
+	if head != bold+"日本"+reset || tail != "語" {
+		t.Errorf("Split() = %q, %q", head, tail)
+	}
+	if Strip(head+tail) != "日本語" {
+		t.Errorf("Strip() = %q", Strip(head+tail))
+	}
+}
@@ -0,0 +1,144 @@
+// Package wrap breaks text into lines of a given display width, on word boundaries, hyphenating
+// words too long to fit on a line, and aligning or justifying the result.
+package wrap
+
+import (
+	"strings"
+
+	symb "github.com/mt1976/crt/strings/symbols"
+	wdth "github.com/mt1976/crt/strings/width"
+)
+
+// Alignment controls how each wrapped line is positioned within the width.
+type Alignment int
+
+const (
+	Left   Alignment = iota // Lines start at the left margin
+	Right                   // Lines end at the right margin
+	Centre                  // Lines are centred between the margins
+	Full                    // Spaces are added between words so lines reach both margins
+)
+
+// Hyphen is added to the end of a line when a word is split across lines.
+var Hyphen = "-"
+
+// Options controls how text is wrapped.
+type Options struct {
+	Width int       // The number of columns available
+	Align Alignment // How lines are positioned within the width
+}
+
+// Wrap breaks text into lines no wider than opts.Width. Repeated spaces are collapsed and any
+// leading indent is kept on every line. A line starting with a bullet gets a hanging indent, so the
+// lines that follow line up with the text after the bullet.
+func Wrap(text string, opts Options) []string {
+	trimmed := strings.TrimLeft(text, " ")
+	first := text[:len(text)-len(trimmed)]
+	if strings.HasPrefix(trimmed, symb.Bullet.Symbol()) {
+		first = first + symb.Bullet.Symbol()
+		trimmed = trimmed[len(symb.Bullet.Symbol()):]
+	}
+	hanging := strings.Repeat(symb.Space.Symbol(), wdth.Of(first))
+
+	words := strings.Fields(trimmed)
+	if len(words) == 0 {
+		return []string{strings.TrimRight(first, " ")}
+	}
+
+	var out []string
+	indent := first
+	for len(words) > 0 {
+		avail := opts.Width - wdth.Of(indent)
+		if avail < 2 {
+			avail = 2
+		}
+		var line []string
+		line, words = fill(words, avail)
+		last := len(words) == 0
+		out = append(out, indent+align(line, avail, opts.Align, last))
+		indent = hanging
+	}
+	return out
+}
+
+// Paragraph wraps each line of a paragraph, returning all the wrapped lines.
+func Paragraph(lines []string, opts Options) []string {
+	var out []string
+	for _, line := range lines {
+		out = append(out, Wrap(line, opts)...)
+	}
+	return out
+}
+
+// fill takes as many words as fit within avail columns, splitting the first word with a hyphen if it
+// is too long to fit on a line of its own. It returns the words for the line and the words left over.
+func fill(words []string, avail int) ([]string, []string) {
+	var line []string
+	used := 0
+	for len(words) > 0 {
+		word := words[0]
+		need := wdth.Of(word)
+		if len(line) > 0 {
+			need++
+		}
+		if used+need <= avail {
+			line = append(line, word)
+			used += need
+			words = words[1:]
+			continue
+		}
+		if len(line) == 0 {
+			head, tail := split(word, avail)
+			line = append(line, head)
+			words = append([]string{tail}, words[1:]...)
+		}
+		break
+	}
+	return line, words
+}
+
+// split breaks a word too long for avail columns, returning the part that goes on this line, ending
+// with a hyphen, and the rest. If there is no room for a character as well as the hyphen the hyphen
+// is dropped, and at least one character is always taken, even if it overflows, so the word gets
+// shorter on every line.
+func split(word string, avail int) (string, string) {
+	head, tail := wdth.Split(word, avail-wdth.Of(Hyphen))
+	if wdth.Of(head) > 0 {
+		return head + Hyphen, tail
+	}
+	for cols := avail; wdth.Of(head) == 0 && tail != ""; cols++ {
+		head, tail = wdth.Split(word, cols)
+	}
+	return head, tail
+}
+
+// align joins the words of a line, positioning them within avail columns. The last line of a fully
+// justified paragraph is left aligned.
+func align(words []string, avail int, alignment Alignment, last bool) string {
+	line := strings.Join(words, symb.Space.Symbol())
+	switch alignment {
+	case Right:
+		return wdth.PadLeft(line, avail)
+	case Centre:
+		return strings.Repeat(symb.Space.Symbol(), wdth.Offset(line, avail)) + line
+	case Full:
+		if last || len(words) < 2 {
+			return line
+		}
+		gaps := len(words) - 1
+		extra := avail - wdth.Of(line)
+		var b strings.Builder
+		for i, word := range words {
+			b.WriteString(word)
+			if i < gaps {
+				spaces := 1 + extra/gaps
+				if i < extra%gaps {
+					spaces++
+				}
+				b.WriteString(strings.Repeat(symb.Space.Symbol(), spaces))
+			}
+		}
+		return b.String()
+	}
+	return line
+}
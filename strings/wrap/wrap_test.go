@@ -0,0 +1,37 @@
+package wrap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Wrap(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		opts Options
+		want []string
+	}{
+		{"Fits", "short text", Options{Width: 20}, []string{"short text"}},
+		{"Breaks on words", "the quick brown fox jumps", Options{Width: 10}, []string{"the quick", "brown fox", "jumps"}},
+		{"Collapses spaces", "a    b", Options{Width: 10}, []string{"a b"}},
+		{"Keeps indent", "  indented text here", Options{Width: 10}, []string{"  indented", "  text", "  here"}},
+		{"Hyphenates long words", "supercalifragilistic", Options{Width: 8}, []string{"superca-", "lifragi-", "listic"}},
+		{"Multibyte not split", "ééééé", Options{Width: 4}, []string{"ééé-", "éé"}},
+		{"Wide runes at narrow width", "日本語", Options{Width: 2}, []string{"日", "本", "語"}},
+		{"Wide rune wider than width", "日本", Options{Width: 1}, []string{"日", "本"}},
+		{"Wide characters", "日本語 日本語", Options{Width: 7}, []string{"日本語", "日本語"}},
+		{"Bullet hanging indent", "- one two three four", Options{Width: 10}, []string{"- one two", "  three", "  four"}},
+		{"Right", "one two three", Options{Width: 9, Align: Right}, []string{"  one two", "    three"}},
+		{"Centre", "one two three", Options{Width: 9, Align: Centre}, []string{" one two", "  three"}},
+		{"Full", "aa b cc dd ee", Options{Width: 9, Align: Full}, []string{"aa  b  cc", "dd ee"}},
+		{"Blank", "", Options{Width: 9}, []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Wrap(tt.text, tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wrap() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
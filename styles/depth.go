@@ -0,0 +1,141 @@
+package styles
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	colr "github.com/fatih/color"
+	"golang.org/x/term"
+)
+
+// Depth is the number of colours a terminal can display.
+type Depth int
+
+const (
+	NoColour   Depth = iota // No colour or attributes, such as when output is redirected to a file
+	Colour16                // The 16 basic ANSI colours
+	Colour256               // The xterm 256 colour palette
+	TrueColour              // 24 bit RGB colour
+)
+
+var (
+	depthLock sync.RWMutex
+	depth     Depth
+)
+
+// ColourDepth returns the colour depth that styles are rendered at.
+func ColourDepth() Depth {
+	depthLock.RLock()
+	defer depthLock.RUnlock()
+	return depth
+}
+
+// Enabled returns true if colours and attributes should be written at all.
+func Enabled() bool {
+	return ColourDepth() != NoColour
+}
+
+// SetColourDepth overrides the detected colour depth. Themes are downsampled to the depth and, at
+// NoColour, the escape code variables in this package are blanked.
+func SetColourDepth(d Depth) {
+	depthLock.Lock()
+	defer depthLock.Unlock()
+	depth = d
+	colr.NoColor = d == NoColour
+	codes := []*string{&Reset, &Red, &Green, &Yellow, &Blue, &Purple, &Cyan, &Gray, &White, &Bold, &Underline, &ClearLine}
+	for i, code := range codes {
+		if d == NoColour {
+			*code = ""
+		} else {
+			*code = escapeCodes[i]
+		}
+	}
+}
+
+// DetectDepth works out the colour depth of the terminal from the environment and whether standard
+// output is a terminal.
+//
+// NO_COLOR turns colour off. FORCE_COLOR turns it on even when output is not a terminal, at the depth
+// given by its value (0 off, 1 for 16 colours, 2 for 256 and 3 for true colour). Otherwise COLORTERM
+// and TERM decide the depth.
+func DetectDepth() Depth {
+	return detectDepth(os.Getenv, term.IsTerminal(int(os.Stdout.Fd())), runtime.GOOS)
+}
+
+func detectDepth(getenv func(string) string, tty bool, goos string) Depth {
+	if getenv("NO_COLOR") != "" {
+		return NoColour
+	}
+	forced := false
+	switch strings.ToLower(getenv("FORCE_COLOR")) {
+	case "":
+	case "0", "false", "no":
+		return NoColour
+	case "2":
+		return Colour256
+	case "3":
+		return TrueColour
+	default:
+		forced = true
+	}
+	if !forced && (!tty || goos == "windows") {
+		return NoColour
+	}
+	termName := strings.ToLower(getenv("TERM"))
+	colorTerm := strings.ToLower(getenv("COLORTERM"))
+	switch {
+	case termName == "dumb" && !forced:
+		return NoColour
+	case colorTerm == "truecolor" || colorTerm == "24bit" || strings.HasSuffix(termName, "-direct"):
+		return TrueColour
+	case strings.Contains(termName, "256color"):
+		return Colour256
+	}
+	return Colour16
+}
+
+// xterm256 returns the index of the closest colour in the xterm 256 colour palette.
+func xterm256(r, g, b uint8) int {
+	if r == g && g == b {
+		switch {
+		case r < 8:
+			return 16
+		case r > 248:
+			return 231
+		}
+		return 232 + (int(r)-8)*24/247
+	}
+	level := func(v uint8) int {
+		switch {
+		case v < 48:
+			return 0
+		case v < 115:
+			return 1
+		}
+		return (int(v) - 35) / 40
+	}
+	return 16 + 36*level(r) + 6*level(g) + level(b)
+}
+
+// basicPalette is the usual xterm rendering of the 16 basic colours, keyed by foreground code.
+var basicPalette = map[int][3]int{
+	30: {0, 0, 0}, 31: {205, 0, 0}, 32: {0, 205, 0}, 33: {205, 205, 0},
+	34: {0, 0, 238}, 35: {205, 0, 205}, 36: {0, 205, 205}, 37: {229, 229, 229},
+	90: {127, 127, 127}, 91: {255, 0, 0}, 92: {0, 255, 0}, 93: {255, 255, 0},
+	94: {92, 92, 255}, 95: {255, 0, 255}, 96: {0, 255, 255}, 97: {255, 255, 255},
+}
+
+// basic16 returns the foreground code of the closest of the 16 basic colours.
+func basic16(r, g, b uint8) int {
+	best, bestDist := 37, -1
+	for code, c := range basicPalette {
+		dr, dg, db := int(r)-c[0], int(g)-c[1], int(b)-c[2]
+		dist := dr*dr + dg*dg + db*db
+		if bestDist < 0 || dist < bestDist || (dist == bestDist && code < best) {
+			best, bestDist = code, dist
+		}
+	}
+	return best
+}
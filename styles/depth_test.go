@@ -0,0 +1,66 @@
+package styles
+
+import "testing"
+
+func Test_detectDepth(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		tty  bool
+		goos string
+		want Depth
+	}{
+		{"Piped to a file", map[string]string{"TERM": "xterm-256color"}, false, "linux", NoColour},
+		{"Basic terminal", map[string]string{"TERM": "xterm"}, true, "linux", Colour16},
+		{"256 colour terminal", map[string]string{"TERM": "xterm-256color"}, true, "linux", Colour256},
+		{"True colour terminal", map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, true, "linux", TrueColour},
+		{"Dumb terminal", map[string]string{"TERM": "dumb"}, true, "linux", NoColour},
+		{"NO_COLOR", map[string]string{"TERM": "xterm-256color", "NO_COLOR": "1"}, true, "linux", NoColour},
+		{"FORCE_COLOR when piped", map[string]string{"FORCE_COLOR": "1"}, false, "linux", Colour16},
+		{"FORCE_COLOR depth", map[string]string{"FORCE_COLOR": "3"}, false, "linux", TrueColour},
+		{"FORCE_COLOR off", map[string]string{"TERM": "xterm-256color", "FORCE_COLOR": "0"}, true, "linux", NoColour},
+		{"Windows", map[string]string{"TERM": "xterm"}, true, "windows", NoColour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := detectDepth(getenv, tt.tty, tt.goos); got != tt.want {
+				t.Errorf("detectDepth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Downsample(t *testing.T) {
+	defer SetColourDepth(ColourDepth())
+	amber := Style{Fg: RGB(0xff, 0xb0, 0x00)}
+	tests := []struct {
+		name  string
+		depth Depth
+		style Style
+		want  string
+	}{
+		{"True colour", TrueColour, amber, "\033[38;2;255;176;0m"},
+		{"256 colours", Colour256, amber, "\033[38;5;214m"},
+		{"16 colours", Colour16, amber, "\033[33m"},
+		{"Grey at 256 colours", Colour256, Style{Fg: RGB(0x80, 0x80, 0x80)}, "\033[38;5;243m"},
+		{"Basic colour is unchanged", Colour256, Style{Fg: Basic(32)}, "\033[32m"},
+		{"No colour", NoColour, Style{Fg: Basic(32), Bold: true}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetColourDepth(tt.depth)
+			if got := tt.style.Sequence(); got != tt.want {
+				t.Errorf("Sequence() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	SetColourDepth(NoColour)
+	if Red != "" || Reset != "" {
+		t.Errorf("escape codes not blanked at NoColour")
+	}
+	SetColourDepth(Colour16)
+	if Red != "\033[1;31m" {
+		t.Errorf("escape codes not restored, Red = %q", Red)
+	}
+}
@@ -1,9 +1,5 @@
 package styles
 
-import (
-	"runtime"
-)
-
 var Reset string = "\033[0m"
 var Red string = "\033[1;31m"
 var Green string = "\033[32m"
@@ -17,19 +13,10 @@ var Bold string = "\033[1m"
 var Underline string = "\033[4m"
 var ClearLine string = "\033[2K"
 
+// escapeCodes holds the original values of the variables above, in the order SetColourDepth sets them.
+var escapeCodes = []string{Reset, Red, Green, Yellow, Blue, Purple, Cyan, Gray, White, Bold, Underline, ClearLine}
+
+// init blanks the escape codes if the terminal cannot display them, or output is not a terminal.
 func init() {
-	if runtime.GOOS == "windows" {
-		Reset = ""
-		Red = ""
-		Green = ""
-		Yellow = ""
-		Blue = ""
-		Purple = ""
-		Cyan = ""
-		Gray = ""
-		White = ""
-		Bold = ""
-		Underline = ""
-		ClearLine = ""
-	}
+	SetColourDepth(DetectDepth())
 }
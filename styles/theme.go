@@ -0,0 +1,372 @@
+package styles
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+)
+
+// Role identifies what a piece of text is for, so that a Theme can decide how it looks.
+type Role int
+
+const (
+	Border    Role = iota // Box drawing around and between areas of the page
+	Title                 // The page title
+	Body                  // Ordinary content
+	Prompt                // The input prompt
+	Error                 // Error messages
+	Warning               // Warning messages
+	Info                  // Informational messages
+	Hint                  // Hints about what can be entered
+	Success               // Messages confirming something worked
+	Highlight             // Content that should stand out, such as menu option numbers
+	Paging                // The page x of y indicator
+)
+
+// roleNames are the names used for roles in theme files.
+var roleNames = map[string]Role{
+	"border":    Border,
+	"title":     Title,
+	"body":      Body,
+	"prompt":    Prompt,
+	"error":     Error,
+	"warning":   Warning,
+	"info":      Info,
+	"hint":      Hint,
+	"success":   Success,
+	"highlight": Highlight,
+	"paging":    Paging,
+}
+
+// Colour is a foreground or background colour. A colour is either one of the 16 basic ANSI colours,
+// given by its SGR foreground code, or a 24 bit RGB colour. The zero value is the terminal's default.
+type Colour struct {
+	code    int // The SGR foreground code of a basic colour, 30-37 or 90-97
+	r, g, b uint8
+	rgb     bool
+}
+
+// Basic returns one of the 16 basic ANSI colours, given its SGR foreground code (30-37 or 90-97).
+func Basic(code int) Colour {
+	return Colour{code: code}
+}
+
+// RGB returns a 24 bit colour.
+func RGB(r, g, b uint8) Colour {
+	return Colour{r: r, g: g, b: b, rgb: true}
+}
+
+// IsDefault returns true if the colour is the terminal's default.
+func (c Colour) IsDefault() bool {
+	return c.code == 0 && !c.rgb
+}
+
+// Style is the colour and attributes used to display text.
+type Style struct {
+	Fg        Colour
+	Bg        Colour
+	Bold      bool
+	Dim       bool
+	Underline bool
+	Reverse   bool
+}
+
+// Sequence returns the escape sequence that switches to the style, or an empty string if the style
+// is plain. Colours are downsampled to the terminal's colour depth.
+func (s Style) Sequence() string {
+	d := ColourDepth()
+	if d == NoColour {
+		return ""
+	}
+	var codes []string
+	if s.Bold {
+		codes = append(codes, "1")
+	}
+	if s.Dim {
+		codes = append(codes, "2")
+	}
+	if s.Underline {
+		codes = append(codes, "4")
+	}
+	if s.Reverse {
+		codes = append(codes, "7")
+	}
+	if !s.Fg.IsDefault() {
+		codes = append(codes, s.Fg.sgr(d, false))
+	}
+	if !s.Bg.IsDefault() {
+		codes = append(codes, s.Bg.sgr(d, true))
+	}
+	if len(codes) == 0 {
+		return ""
+	}
+	return "\033[" + strings.Join(codes, ";") + "m"
+}
+
+// Render returns the text in the style, resetting the style afterwards.
+func (s Style) Render(text string) string {
+	seq := s.Sequence()
+	if seq == "" {
+		return text
+	}
+	return seq + text + Reset
+}
+
+// sgr returns the SGR parameters that select the colour at the given depth.
+func (c Colour) sgr(d Depth, background bool) string {
+	code := c.code
+	if c.rgb {
+		layer := "38"
+		if background {
+			layer = "48"
+		}
+		switch d {
+		case TrueColour:
+			return fmt.Sprintf("%s;2;%d;%d;%d", layer, c.r, c.g, c.b)
+		case Colour256:
+			return fmt.Sprintf("%s;5;%d", layer, xterm256(c.r, c.g, c.b))
+		}
+		code = basic16(c.r, c.g, c.b)
+	}
+	if background {
+		return strconv.Itoa(code + 10)
+	}
+	return strconv.Itoa(code)
+}
+
+// Theme maps each Role to the Style used to display it.
+type Theme struct {
+	Name  string
+	Roles map[Role]Style
+}
+
+// Style returns the style for the role, or a plain style if the theme does not define it.
+func (t *Theme) Style(role Role) Style {
+	return t.Roles[role]
+}
+
+// Render returns the text in the theme's style for the role.
+func (t *Theme) Render(role Role, text string) string {
+	return t.Style(role).Render(text)
+}
+
+var (
+	themesLock sync.RWMutex
+	themes     = map[string]*Theme{}
+	active     *Theme
+	configured *chosen // The theme chosen by the configuration, until the configuration or themes change
+)
+
+// chosen is the theme chosen by the ThemeFile and Theme settings, so the file is only read, or
+// found to be missing or invalid, once.
+type chosen struct {
+	themeFile string
+	theme     string
+	t         *Theme
+}
+
+func init() {
+	for _, t := range []*Theme{Default, GreenPhosphor, AmberPhosphor, IBM3270, Monochrome} {
+		Register(t)
+	}
+	// Read the theme file again when the configuration, or the file itself, changes
+	conf.OnChange(func(conf.Change) {
+		themesLock.Lock()
+		defer themesLock.Unlock()
+		configured = nil
+	})
+	conf.AddCheck("Theme", func(c conf.Config) error {
+		if c.Theme == "" {
+			return nil
+		}
+		_, err := Lookup(c.Theme)
+		return err
+	})
+	conf.AddCheck("ThemeFile", func(c conf.Config) error {
+		if c.ThemeFile == "" {
+			return nil
+		}
+		_, err := readTheme(c.ThemeFile)
+		return err
+	})
+}
+
+// Register adds a theme, replacing any theme already registered with the same name.
+func Register(t *Theme) {
+	themesLock.Lock()
+	defer themesLock.Unlock()
+	themes[strings.ToLower(t.Name)] = t
+	configured = nil
+}
+
+// Themes returns the names of the registered themes, in alphabetical order.
+func Themes() []string {
+	themesLock.RLock()
+	defer themesLock.RUnlock()
+	var names []string
+	for _, t := range themes {
+		names = append(names, t.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the registered theme with the given name, ignoring case.
+func Lookup(name string) (*Theme, error) {
+	themesLock.RLock()
+	defer themesLock.RUnlock()
+	t, ok := themes[strings.ToLower(name)]
+	if !ok {
+		return nil, errs.ErrUnknownTheme.With(name)
+	}
+	return t, nil
+}
+
+// SetTheme makes the named theme the active theme.
+func SetTheme(name string) error {
+	t, err := Lookup(name)
+	if err != nil {
+		return err
+	}
+	themesLock.Lock()
+	defer themesLock.Unlock()
+	active = t
+	return nil
+}
+
+// Active returns the active theme. Until one is chosen with SetTheme, this is the theme loaded from
+// the ThemeFile, or named by Theme, in the configuration, or the Default theme. The configured theme
+// is only looked for again when the configuration changes.
+func Active() *Theme {
+	c := conf.Current()
+	themesLock.RLock()
+	t, ch := active, configured
+	themesLock.RUnlock()
+	if t != nil {
+		return t
+	}
+	if ch != nil && ch.themeFile == c.ThemeFile && ch.theme == c.Theme {
+		return ch.t
+	}
+	ch = &chosen{themeFile: c.ThemeFile, theme: c.Theme, t: choose(c.ThemeFile, c.Theme)}
+	themesLock.Lock()
+	configured = ch
+	themesLock.Unlock()
+	return ch.t
+}
+
+// choose returns the theme loaded from themeFile, or if it can not be loaded the registered theme
+// named name, or the Default theme. Both are checked when the configuration is validated, so the
+// fallbacks are only used if the file has changed since, or the configuration was not loaded.
+func choose(themeFile, name string) *Theme {
+	if themeFile != "" {
+		if t, err := LoadTheme(themeFile); err == nil {
+			return t
+		}
+	}
+	if name != "" {
+		if t, err := Lookup(name); err == nil {
+			return t
+		}
+	}
+	return Default
+}
+
+// Render returns the text in the active theme's style for the role.
+func Render(role Role, text string) string {
+	return Active().Render(role, text)
+}
+
+// themeFile is the layout of a theme stored as JSON, for example
+//
+//	{"name": "Corporate", "roles": {"body": {"fg": "#33ff33"}, "error": {"fg": "red", "bold": true}}}
+type themeFile struct {
+	Name  string               `json:"name"`
+	Roles map[string]styleFile `json:"roles"`
+}
+
+type styleFile struct {
+	Fg        string `json:"fg"`
+	Bg        string `json:"bg"`
+	Bold      bool   `json:"bold"`
+	Dim       bool   `json:"dim"`
+	Underline bool   `json:"underline"`
+	Reverse   bool   `json:"reverse"`
+}
+
+// LoadTheme reads a theme from a JSON file and registers it. Colours are given as #rrggbb or as the
+// name of a basic colour, such as green or bright-green.
+func LoadTheme(path string) (*Theme, error) {
+	t, err := readTheme(path)
+	if err != nil {
+		return nil, err
+	}
+	Register(t)
+	return t, nil
+}
+
+// readTheme reads a theme from a JSON file, without registering it.
+func readTheme(path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var tf themeFile
+	if err := json.Unmarshal(data, &tf); err != nil {
+		return nil, errs.ErrInvalidTheme.With(path).Wrap(err)
+	}
+	if tf.Name == "" {
+		return nil, errs.ErrInvalidTheme.With(path).Wrap(errors.New("the theme has no name"))
+	}
+	t := &Theme{Name: tf.Name, Roles: map[Role]Style{}}
+	for name, sf := range tf.Roles {
+		role, ok := roleNames[strings.ToLower(name)]
+		if !ok {
+			return nil, errs.ErrInvalidTheme.With(path).Wrap(fmt.Errorf("unknown role %v", name))
+		}
+		fg, err := parseColour(sf.Fg)
+		if err != nil {
+			return nil, errs.ErrInvalidTheme.With(path).Wrap(err)
+		}
+		bg, err := parseColour(sf.Bg)
+		if err != nil {
+			return nil, errs.ErrInvalidTheme.With(path).Wrap(err)
+		}
+		t.Roles[role] = Style{Fg: fg, Bg: bg, Bold: sf.Bold, Dim: sf.Dim, Underline: sf.Underline, Reverse: sf.Reverse}
+	}
+	return t, nil
+}
+
+// basicNames are the names accepted for the basic colours in theme files.
+var basicNames = map[string]int{
+	"black": 30, "red": 31, "green": 32, "yellow": 33, "blue": 34, "magenta": 35, "cyan": 36, "white": 37,
+	"bright-black": 90, "bright-red": 91, "bright-green": 92, "bright-yellow": 93,
+	"bright-blue": 94, "bright-magenta": 95, "bright-cyan": 96, "bright-white": 97,
+}
+
+// parseColour converts a colour given as #rrggbb or a basic colour name. An empty string is the
+// terminal's default colour.
+func parseColour(s string) (Colour, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == "default" {
+		return Colour{}, nil
+	}
+	if code, ok := basicNames[s]; ok {
+		return Basic(code), nil
+	}
+	if len(s) == 7 && s[0] == '#' {
+		v, err := strconv.ParseUint(s[1:], 16, 32)
+		if err == nil {
+			return RGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+		}
+	}
+	return Colour{}, fmt.Errorf("invalid colour %q", s)
+}
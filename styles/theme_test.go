@@ -0,0 +1,94 @@
+package styles
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	conf "github.com/mt1976/crt/config"
+	errs "github.com/mt1976/crt/errors"
+)
+
+func Test_Sequence(t *testing.T) {
+	defer SetColourDepth(ColourDepth())
+	SetColourDepth(TrueColour)
+	tests := []struct {
+		name  string
+		style Style
+		want  string
+	}{
+		{"Plain", Style{}, ""},
+		{"Basic", Style{Fg: Basic(32)}, "\033[32m"},
+		{"Basic background", Style{Bg: Basic(34)}, "\033[44m"},
+		{"RGB bold", Style{Fg: RGB(0xff, 0xb0, 0x00), Bold: true}, "\033[1;38;2;255;176;0m"},
+		{"Attributes only", Style{Underline: true, Reverse: true}, "\033[4;7m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.Sequence(); got != tt.want {
+				t.Errorf("Sequence() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_LoadTheme(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corporate.json")
+	data := `{"name": "Corporate", "roles": {"body": {"fg": "#33ff33"}, "error": {"fg": "bright-red", "bold": true}}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	theme, err := LoadTheme(path)
+	if err != nil {
+		t.Fatalf("LoadTheme() error = %v", err)
+	}
+	if got := theme.Style(Body).Fg; got != RGB(0x33, 0xff, 0x33) {
+		t.Errorf("body = %v", got)
+	}
+	if got := theme.Style(Error); got.Fg != Basic(91) || !got.Bold {
+		t.Errorf("error = %v", got)
+	}
+	if err := SetTheme("corporate"); err != nil {
+		t.Errorf("SetTheme() error = %v", err)
+	}
+	if Active() != theme {
+		t.Errorf("Active() = %v, want %v", Active().Name, theme.Name)
+	}
+	if err := SetTheme("nonesuch"); err == nil {
+		t.Errorf("SetTheme() of an unknown theme did not fail")
+	}
+}
+
+func Test_ActiveConfigured(t *testing.T) {
+	themesLock.Lock()
+	active = nil
+	themesLock.Unlock()
+	dir := t.TempDir()
+	themePath := filepath.Join(dir, "corporate.json")
+	os.WriteFile(filepath.Join(dir, "terminal.env"), []byte("ThemeFile="+themePath+"\nTheme=AmberPhosphor\n"), 0o644)
+	opts := conf.Options{Paths: []string{dir}, UserPaths: []string{}, SystemPaths: []string{}}
+	defer conf.Load(conf.Options{Paths: []string{}, UserPaths: []string{}, SystemPaths: []string{}})
+
+	if err := conf.Load(opts); !errors.Is(err, errs.ErrInvalidConfig) {
+		t.Errorf("Load() with a missing theme file = %v, want ErrInvalidConfig", err)
+	}
+	os.WriteFile(themePath, []byte(`{"name": "Corporate"}`), 0o644)
+	if err := conf.Load(opts); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(themePath, []byte(`not json`), 0o644)
+	if got := Active(); got != AmberPhosphor {
+		t.Errorf("Active() with a theme file broken since loading = %v, want AmberPhosphor", got.Name)
+	}
+	os.WriteFile(themePath, []byte(`{"name": "Corporate"}`), 0o644)
+	if got := Active(); got != AmberPhosphor {
+		t.Errorf("Active() = %v, read the theme file again before the configuration changed", got.Name)
+	}
+	if err := conf.Load(opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := Active(); got.Name != "Corporate" {
+		t.Errorf("Active() after reloading = %v, want Corporate", got.Name)
+	}
+}
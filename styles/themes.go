@@ -0,0 +1,94 @@
+package styles
+
+// Default looks the same as pages always have, green text with coloured messages.
+var Default = &Theme{
+	Name: "Default",
+	Roles: map[Role]Style{
+		Border:    {Fg: Basic(32)},
+		Title:     {Fg: Basic(32), Bold: true},
+		Body:      {Fg: Basic(32)},
+		Prompt:    {Fg: Basic(32)},
+		Error:     {Fg: Basic(31), Bold: true},
+		Warning:   {Fg: Basic(33)},
+		Info:      {Fg: Basic(37)},
+		Hint:      {Fg: Basic(36)},
+		Success:   {Fg: Basic(32), Bold: true},
+		Highlight: {Fg: Basic(32)},
+		Paging:    {Fg: Basic(33)},
+	},
+}
+
+// GreenPhosphor is a single colour green screen, like a P1 phosphor monitor. Messages are told apart
+// by brightness, underlining and reverse video rather than colour.
+var GreenPhosphor = &Theme{
+	Name: "GreenPhosphor",
+	Roles: map[Role]Style{
+		Border:    {Fg: RGB(0x1a, 0x8c, 0x1a)},
+		Title:     {Fg: RGB(0x66, 0xff, 0x66), Bold: true},
+		Body:      {Fg: RGB(0x33, 0xff, 0x33)},
+		Prompt:    {Fg: RGB(0x66, 0xff, 0x66), Bold: true},
+		Error:     {Fg: RGB(0x33, 0xff, 0x33), Bold: true, Reverse: true},
+		Warning:   {Fg: RGB(0x66, 0xff, 0x66), Bold: true, Underline: true},
+		Info:      {Fg: RGB(0x33, 0xff, 0x33)},
+		Hint:      {Fg: RGB(0x1a, 0x8c, 0x1a)},
+		Success:   {Fg: RGB(0x99, 0xff, 0x99), Bold: true},
+		Highlight: {Fg: RGB(0x99, 0xff, 0x99), Bold: true},
+		Paging:    {Fg: RGB(0x1a, 0x8c, 0x1a)},
+	},
+}
+
+// AmberPhosphor is a single colour amber screen, like a P3 phosphor monitor.
+var AmberPhosphor = &Theme{
+	Name: "AmberPhosphor",
+	Roles: map[Role]Style{
+		Border:    {Fg: RGB(0xb3, 0x7b, 0x00)},
+		Title:     {Fg: RGB(0xff, 0xcc, 0x33), Bold: true},
+		Body:      {Fg: RGB(0xff, 0xb0, 0x00)},
+		Prompt:    {Fg: RGB(0xff, 0xcc, 0x33), Bold: true},
+		Error:     {Fg: RGB(0xff, 0xb0, 0x00), Bold: true, Reverse: true},
+		Warning:   {Fg: RGB(0xff, 0xcc, 0x33), Bold: true, Underline: true},
+		Info:      {Fg: RGB(0xff, 0xb0, 0x00)},
+		Hint:      {Fg: RGB(0xb3, 0x7b, 0x00)},
+		Success:   {Fg: RGB(0xff, 0xdd, 0x77), Bold: true},
+		Highlight: {Fg: RGB(0xff, 0xdd, 0x77), Bold: true},
+		Paging:    {Fg: RGB(0xb3, 0x7b, 0x00)},
+	},
+}
+
+// IBM3270 follows the colours of an IBM 3279 display station: blue for protected fields, green for
+// input, white for intensified text, turquoise, red, yellow and pink for everything else.
+var IBM3270 = &Theme{
+	Name: "IBM3270",
+	Roles: map[Role]Style{
+		Border:    {Fg: RGB(0x5c, 0x8a, 0xff)},
+		Title:     {Fg: RGB(0xff, 0xff, 0xff), Bold: true},
+		Body:      {Fg: RGB(0x00, 0xff, 0x00)},
+		Prompt:    {Fg: RGB(0x40, 0xe0, 0xd0)},
+		Error:     {Fg: RGB(0xff, 0x30, 0x30), Bold: true},
+		Warning:   {Fg: RGB(0xff, 0xff, 0x00)},
+		Info:      {Fg: RGB(0x40, 0xe0, 0xd0)},
+		Hint:      {Fg: RGB(0xff, 0x80, 0xc0)},
+		Success:   {Fg: RGB(0x00, 0xff, 0x00), Bold: true},
+		Highlight: {Fg: RGB(0xff, 0xff, 0xff), Bold: true},
+		Paging:    {Fg: RGB(0xff, 0xff, 0x00)},
+	},
+}
+
+// Monochrome uses no colour at all, only bold, dim, underlined and reverse video text, in the
+// terminal's own colours.
+var Monochrome = &Theme{
+	Name: "Monochrome",
+	Roles: map[Role]Style{
+		Border:    {},
+		Title:     {Bold: true},
+		Body:      {},
+		Prompt:    {Bold: true},
+		Error:     {Bold: true, Reverse: true},
+		Warning:   {Bold: true, Underline: true},
+		Info:      {},
+		Hint:      {Dim: true},
+		Success:   {Bold: true},
+		Highlight: {Bold: true},
+		Paging:    {Dim: true},
+	},
+}
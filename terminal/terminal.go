@@ -5,7 +5,6 @@ import (
 	"os"
 	"runtime"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +18,8 @@ import (
 	lang "github.com/mt1976/crt/language"
 	strg "github.com/mt1976/crt/strings"
 	symb "github.com/mt1976/crt/strings/symbols"
+	wdth "github.com/mt1976/crt/strings/width"
+	wrap "github.com/mt1976/crt/strings/wrap"
 
 	"golang.org/x/term"
 )
@@ -26,8 +27,6 @@ import (
 const StartColumn int = 1
 const InputColumn int = StartColumn + 2
 
-var config = conf.Configuration
-
 // The "visibleContent" type represents a visibleContent with a map of rows and columns.
 // @property row - The "row" property is a map that stores the values of each row in the visibleContent. The keys
 // of the map are integers representing the row numbers, and the values are strings representing the
@@ -70,6 +69,7 @@ type ViewPort struct {
 	currentRow     int              // the current row of the terminal
 	currentCol     int              // the current column of the terminal
 	visibleContent *visibleContent  // the current screen content
+	boxStyle       boxr.BoxStyle    // the characters used to draw boxes
 	Helpers        *hlpr.Helpers    // Helper functions
 	Formatters     *hlpr.Formatters // Formatter functions
 	Styles         *hlpr.Styles     // Colour functions
@@ -99,6 +99,7 @@ func New() ViewPort {
 	x.defaultBaud()  // set baud to 9600
 
 	x.newPageContent(x.width, x.height)
+	x.boxStyle = boxr.Default()
 	x.Helpers = hlpr.InitHelpers()
 	x.Formatters = hlpr.InitFormatters()
 	x.Styles = hlpr.InitStyles()
@@ -124,12 +125,22 @@ func NewWithSize(width, height int) ViewPort {
 // The `row()` function is a method of the `Crt` struct. It is used to generate a formatted string that
 // represents a row on the terminal.
 func (t *ViewPort) row() string {
-	displayChar := boxr.DividerLeft
+	displayChar := t.boxStyle.DividerLeft
 	if t.firstRow {
-		displayChar = boxr.StartLeft
+		displayChar = t.boxStyle.StartLeft
 		t.firstRow = false
 	}
-	return displayChar + strings.Repeat(boxr.Horizontal, t.width-3)
+	return displayChar + strings.Repeat(t.boxStyle.Horizontal, t.width-3)
+}
+
+// SetBoxStyle sets the characters used to draw boxes on the terminal.
+func (t *ViewPort) SetBoxStyle(style boxr.BoxStyle) {
+	t.boxStyle = style
+}
+
+// BoxStyle returns the characters used to draw boxes on the terminal.
+func (t *ViewPort) BoxStyle() boxr.BoxStyle {
+	return t.boxStyle
 }
 
 // The `Close()` function is a method of the `Crt` struct. It is used to print a closing line on the
@@ -151,7 +162,7 @@ func (t *ViewPort) SetDelayInMs(delayMs int) {
 // `height`, which represent the desired width and height of the terminal screen.
 func (t *ViewPort) SetTerminalSize(width, height int) {
 	if !(width > 0 && height > 0) {
-		t.Error(errs.ErrTerminalSize, strconv.Itoa(width), strconv.Itoa(height))
+		t.Error(errs.ErrTerminalSize.With(width, height))
 		os.Exit(1)
 	}
 	t.width = width
@@ -239,20 +250,11 @@ func (t *ViewPort) Print(msg string) {
 	t.PrintIt(t.Format(msg, ""))
 }
 
-// Paragraph formats a list of strings as paragraphs, wrapping lines as needed to fit within the
-// terminal width.
+// Paragraph formats a list of strings as paragraphs, wrapping lines on word boundaries to fit within
+// the terminal width.
 func (t *ViewPort) Paragraph(msg []string) {
-	// make sure the lines are no longer than the screen width and wrap them if they are.
-	out := []string{}
-	for _, s := range msg {
-		s = t.Formatters.TrimRepeatingCharacters(s, symb.Space.Symbol())
-		if len(s) > t.Width() {
-			out = append(out, s[:t.Width()])
-			out = append(out, s[t.Width():])
-		} else {
-			out = append(out, s)
-		}
-	}
+	// allow for the upright and space either side of the text
+	out := wrap.Paragraph(msg, wrap.Options{Width: t.Width() - 4})
 
 	for _, s := range out {
 		t.Println(t.Format(s, ""))
@@ -264,7 +266,7 @@ func (t *ViewPort) Paragraph(msg []string) {
 // special character (`chSpecial`) using the `Format` method of the `Crt` struct. This function is used
 // to print a special message or highlight certain text on the terminal.
 func (t *ViewPort) Special(msg string) {
-	t.Println(t.Format(msg, boxr.DividerLeft) + symb.Newline.Symbol())
+	t.Println(t.Format(msg, t.boxStyle.DividerLeft) + symb.Newline.Symbol())
 }
 
 // The `Input` function is a method of the `Crt` struct. It is used to display a prompt for the user for input on the
@@ -295,9 +297,11 @@ func (t *ViewPort) InputError(err error, msg ...string) {
 	pp := t.SError(err, msg...)
 	gtrm.Print(pp)
 	gtrm.Flush()
-	beep.Beep(config.DefaultBeepFrequency, config.DefaultBeepDuration)
+	if errs.SeverityOf(err) >= errs.Warning {
+		beep.Beep(conf.Current().DefaultBeepFrequency, conf.Current().DefaultBeepDuration)
+	}
 	oldDelay := t.Delay()
-	t.SetDelayInSec(config.DefaultErrorDelay)
+	t.SetDelayInSec(conf.Current().DefaultErrorDelay)
 	t.DelayIt()
 	t.SetDelayInMs(oldDelay)
 }
@@ -329,8 +333,8 @@ func (t *ViewPort) InfoMessage(msg string) {
 // Returns:
 // None.
 func (t *ViewPort) InputPagingInfo(page, ofPages int) {
-	msg := fmt.Sprintf(lang.Paging.Text(), page, ofPages)
-	lmsg := len(msg)
+	msg := lang.Paging.Format(page, ofPages)
+	lmsg := wdth.Of(msg)
 	gtrm.MoveCursor(t.width-lmsg-1, 22)
 	//gT.MoveCursor(col, 23)
 	gtrm.Print(
@@ -341,18 +345,18 @@ func (t *ViewPort) InputPagingInfo(page, ofPages int) {
 
 // lineBreakEnd returns a string that represents a line break with the end character.
 func (t *ViewPort) lineBreakEnd() string {
-	return t.lineBreakJunction(boxr.EndLeft)
+	return t.lineBreakJunction(t.boxStyle.EndLeft)
 }
 
 // lineBreakJunction returns a string that represents a line break with the end character.
 func (t *ViewPort) lineBreakJunction(displayChar string) string {
-	return fmt.Sprintf(lang.LineConstructor.Text(), displayChar, strings.Repeat(boxr.Horizontal, t.width+1), boxr.Horizontal)
+	return fmt.Sprintf(lang.LineConstructor.Text(), displayChar, strings.Repeat(t.boxStyle.Horizontal, t.width+1), t.boxStyle.Horizontal)
 }
 
 // The `Format` function is a method of the `Crt` struct. It takes two parameters: `in` of type string
 // and `t` of type string.
 func (t *ViewPort) Format(msg string, text string) string {
-	char := boxr.Upright
+	char := t.boxStyle.Upright
 	if text != "" {
 		char = text
 	}
@@ -386,28 +390,30 @@ func (t *ViewPort) Error(err error, msg ...string) {
 	t.Println(t.row())
 }
 
+// SError returns err as a message, labelled and coloured by its severity.
 func (t *ViewPort) SError(err error, msg ...string) string {
-	errText := err.Error()
-	msgr := t.Styles.Red(lang.Error.Text())
-	return t.fmtMessage(errText, msgr, "", msg...)
-}
-
-func (t *ViewPort) fmtMessage(errText, promptTxt, colour string, msg ...string) string {
-
-	if len(msg) > 0 {
-		// check for enough %v strings in the error
-		// if not enough then add them on the end
-		noVars := strings.Count(errText, "%v")
-
-		if noVars < len(msg) {
-			errText = errText + strings.Repeat(" %v", len(msg)-noVars)
-		}
+	var msgr string
+	switch errs.SeverityOf(err) {
+	case errs.Info:
+		msgr = t.Styles.Cyan(lang.Info.Text())
+	case errs.Warning:
+		msgr = t.Styles.Yellow(lang.Warning.Text())
+	case errs.Fatal:
+		msgr = t.Styles.Red(t.Styles.Bold(lang.Fatal.Text()))
+	default:
+		msgr = t.Styles.Red(lang.Error.Text())
 	}
-	qq := errText
+	args := make([]any, len(msg))
 	for i := range msg {
-		qq = strings.Replace(qq, "%v", fmt.Sprintf("%v", msg[i]), 1)
+		args[i] = msg[i]
 	}
-	errText = (colour + promptTxt + t.Styles.Reset) + qq
+	return t.fmtMessage(errs.Format(err, args...), msgr, "")
+}
+
+// fmtMessage puts the coloured prompt in front of a message, whose placeholders have already been
+// filled in.
+func (t *ViewPort) fmtMessage(errText, promptTxt, colour string) string {
+	errText = (colour + promptTxt + t.Styles.Reset) + errText
 	errText = t.Format(errText, "")
 	return errText
 }
@@ -466,7 +472,7 @@ func (t *ViewPort) Banner(msg string) {
 	}
 	fmt.Println(t.row())
 	//gtrm.Flush()
-	display := fmt.Sprintf(lang.ApplicationVersion.Text(), msg)
+	display := lang.ApplicationVersion.Format(msg)
 	fmt.Println(t.Format(display+symb.Newline.Symbol(), ""))
 	//t.Break()
 	//gtrm.Flush()
@@ -479,28 +485,11 @@ func (t *ViewPort) Header(msg string) {
 	gtrm.MoveCursor(1, 1)
 	gtrm.Println(t.row()) // + lang.SymNewline.String())
 	gtrm.MoveCursor(StartColumn, 2)
-	var line map[int]string = make(map[int]string)
-	midway := (t.width - len(msg)) / 2
-	for i := 0; i < len(lang.ApplicationName.Text()); i++ {
-		line[i] = lang.ApplicationName.Text()[i : i+1]
-	}
-	for i := 0; i < len(msg); i++ {
-		line[midway+i] = msg[i : i+1]
-	}
-
-	// Add DateTimeStamp to end of string
-	for i := 0; i < len(dttm.DateTimeString()); i++ {
-		line[t.width-len(dttm.DateTimeString())+i] = dttm.DateTimeString()[i : i+1]
-	}
-
-	//map to string
-	var headerRowString string
-	for i := 0; i < t.width; i++ {
-		if line[i] == "" {
-			line[i] = symb.Space.Symbol()
-		}
-		headerRowString = headerRowString + line[i]
-	}
+	// Application name on the left, the message centred and the DateTimeStamp on the right
+	dateTime := dttm.DateTimeString()
+	midway := wdth.Offset(msg, t.width)
+	headerRowString := wdth.Fit(lang.ApplicationName.Text(), midway) + msg
+	headerRowString = wdth.Fit(headerRowString, t.width-wdth.Of(dateTime)) + dateTime
 
 	gtrm.Print(t.Styles.Bold(headerRowString) + symb.Newline.Symbol())
 	gtrm.Flush()
@@ -511,14 +500,34 @@ func (t *ViewPort) Header(msg string) {
 //
 // If the specified baud rate is not supported, an error is returned and the CRT's baud rate is reset to the default value.
 func (t *ViewPort) SetBaud(baudRate int) {
-	if sort.SearchInts(config.ValidBaudRates, baudRate) == -1 {
-		t.Error(errs.ErrBaudRateError, strconv.Itoa(baudRate))
+	if sort.SearchInts(conf.Current().ValidBaudRates, baudRate) == -1 {
+		t.Error(errs.ErrBaudRateError.With(baudRate))
 		t.defaultBaud()
 		return
 	}
 	t.baudRate = baudRate
 }
 
+// ApplyConfig applies the settings that differ between two configurations to the viewport: the
+// delay, the baud rate and the box style.
+func (t *ViewPort) ApplyConfig(previous, current conf.Config) {
+	if current.Delay != previous.Delay {
+		t.SetDelayInSec(current.Delay)
+	}
+	baud := func(c conf.Config) int {
+		if c.Baud > 0 {
+			return c.Baud
+		}
+		return c.DefaultBaud
+	}
+	if baud(current) != baud(previous) {
+		t.SetBaud(baud(current))
+	}
+	if current.BoxStyle != previous.BoxStyle {
+		t.SetBoxStyle(boxr.Configured(current))
+	}
+}
+
 // Baud returns the current baud rate of the CRT.
 func (t *ViewPort) Baud() int {
 	return t.baudRate
@@ -528,7 +537,7 @@ func (t *ViewPort) Baud() int {
 //
 // If the specified baud rate is not supported, an error is returned and the CRT's baud rate is reset to the default value.
 func (t *ViewPort) defaultBaud() {
-	t.baudRate = config.DefaultBaud
+	t.baudRate = conf.Current().DefaultBaud
 }
 
 // PrintIt prints a message to the terminal.
@@ -547,13 +556,13 @@ func (t *ViewPort) PrintIt(msg string) {
 	//log.Printf("t.width: %v\n", t.width)
 	//log.Printf("msg: %v\n", msg)
 	//log.Printf("t.currentRow: %v\n", t.currentRow)
-	if len(rowString) < t.width {
-		rowString = rowString + strings.Repeat(".", t.width-(len(rowString)+1))
+	if wdth.Of(rowString) < t.width {
+		rowString = rowString + strings.Repeat(".", t.width-(wdth.Of(rowString)+1))
 	} else {
-		rowString = rowString[0:t.width]
+		rowString = wdth.Truncate(rowString, t.width)
 	}
 	//t.Print(rowString + msg
-	rowString = rowString + boxr.Upright
+	rowString = rowString + t.boxStyle.Upright
 	//log.Printf("rowString: [%v]\n", rowString)
 	//log.Printf("len(rowString): %v\n", len(rowString))
 	if t.NoBaudRate() {